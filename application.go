@@ -2,6 +2,7 @@ package cview
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -269,6 +270,8 @@ func (a *Application) init() error {
 }
 
 // EnableBracketedPaste enables bracketed paste mode, which is enabled by default.
+// While enabled, pasted text is passed to the focused primitive at once when it
+// provides an InsertText(text string) method, such as InputField.
 func (a *Application) EnableBracketedPaste(enable bool) {
 	a.Lock()
 	defer a.Unlock()
@@ -282,6 +285,64 @@ func (a *Application) EnableBracketedPaste(enable bool) {
 	a.enableBracketedPaste = enable
 }
 
+// paste passes the keys received during a bracketed paste to the given
+// primitive. Primitives which provide an InsertText method receive the pasted
+// text at once. Other primitives receive the keys one by one. Like other keys,
+// pasted keys are passed to the input capture functions of the Application
+// and of the primitive first.
+func (a *Application) paste(p Primitive, events []*tcell.EventKey) {
+	if p == nil {
+		return
+	}
+
+	a.RLock()
+	inputCapture := a.inputCapture
+	a.RUnlock()
+	if inputCapture != nil {
+		var captured []*tcell.EventKey
+		for _, event := range events {
+			if event = inputCapture(event); event != nil {
+				captured = append(captured, event)
+			}
+		}
+		events = captured
+	}
+
+	if inserter, ok := p.(interface{ InsertText(text string) }); ok {
+		capturer, _ := p.(interface {
+			captureInput(event *tcell.EventKey) *tcell.EventKey
+		})
+		var text strings.Builder
+		for _, event := range events {
+			if capturer != nil {
+				if event = capturer.captureInput(event); event == nil {
+					continue
+				}
+			}
+			switch event.Key() {
+			case tcell.KeyRune:
+				text.WriteRune(event.Rune())
+			case tcell.KeyEnter:
+				text.WriteRune('\n')
+			case tcell.KeyTab:
+				text.WriteRune('\t')
+			}
+		}
+		inserter.InsertText(text.String())
+		return
+	}
+
+	handler := p.InputHandler()
+	if handler == nil {
+		return
+	}
+	for _, event := range events {
+		handler(event, func(p Primitive) {
+			a.SetFocus(p)
+		})
+	}
+}
+
 // EnableMouse enables mouse events.
 func (a *Application) EnableMouse(enable bool) {
 	a.Lock()
@@ -358,6 +419,12 @@ func (a *Application) Run() error {
 		}
 	}()
 
+	// Key events received during a bracketed paste.
+	var (
+		pasting bool
+		pasted  []*tcell.EventKey
+	)
+
 	handle := func(event interface{}) {
 		a.RLock()
 		p := a.focus
@@ -366,7 +433,24 @@ func (a *Application) Run() error {
 		a.RUnlock()
 
 		switch event := event.(type) {
+		case *tcell.EventPaste:
+			if event.Start() {
+				pasting = true
+				pasted = nil
+				return
+			}
+			pasting = false
+
+			a.paste(p, pasted)
+			pasted = nil
+			a.draw()
 		case *tcell.EventKey:
+			// Collect pasted keys.
+			if pasting {
+				pasted = append(pasted, event)
+				return
+			}
+
 			// Intercept keys.
 			if inputCapture != nil {
 				event = inputCapture(event)
@@ -813,8 +897,27 @@ func (a *Application) GetAfterResizeFunc() func(width int, height int) {
 // events.
 //
 // Blur() will be called on the previously focused primitive. Focus() will be
-// called on the new primitive.
+// called on the new primitive. Blur() is called without holding the lock of
+// the Application, so the previously focused primitive's handlers may call
+// SetFocus themselves, in which case their focus takes precedence.
 func (a *Application) SetFocus(p Primitive) {
+	a.RLock()
+	previous := a.focus
+	a.RUnlock()
+
+	a.setFocus(p, previous)
+}
+
+// previousFocusRecorder is implemented by primitives which record the
+// primitive which had focus before they were focused, e.g. Modal.
+type previousFocusRecorder interface {
+	setPreviousFocus(p Primitive)
+}
+
+// setFocus sets the focus on a new primitive. The primitive which had focus
+// before the delegation chain started is passed along so that a primitive
+// further down the chain can record it (see previousFocusRecorder).
+func (a *Application) setFocus(p Primitive, previous Primitive) {
 	a.Lock()
 
 	if a.beforeFocus != nil {
@@ -826,14 +929,30 @@ func (a *Application) SetFocus(p Primitive) {
 		a.Lock()
 	}
 
-	if a.focus != nil {
-		a.focus.Blur()
+	if r, ok := p.(previousFocusRecorder); ok {
+		r.setPreviousFocus(previous)
 	}
 
+	blurred := a.focus
 	a.focus = p
 
+	// Hide the cursor and reset its style. The newly focused primitive may
+	// show the cursor again when it is drawn.
 	if a.screen != nil {
 		a.screen.HideCursor()
+		a.screen.SetCursorStyle(tcell.CursorStyleDefault)
+	}
+
+	if blurred != nil {
+		a.Unlock()
+		blurred.Blur()
+		a.Lock()
+
+		// A handler called during Blur may have shifted the focus.
+		if a.focus != p {
+			a.Unlock()
+			return
+		}
 	}
 
 	if a.afterFocus != nil {
@@ -846,7 +965,7 @@ func (a *Application) SetFocus(p Primitive) {
 
 	if p != nil {
 		p.Focus(func(p Primitive) {
-			a.SetFocus(p)
+			a.setFocus(p, previous)
 		})
 	}
 }
@@ -60,11 +60,19 @@ type Box struct {
 	// Whether or not this box shows its focus.
 	showFocus bool
 
+	// The style of the cursor shown while this box has focus.
+	cursorStyle tcell.CursorStyle
+
 	// An optional capture function which receives a key event and returns the
 	// event to be forwarded to the primitive's default input handler (nil if
 	// nothing should be forwarded).
 	inputCapture func(event *tcell.EventKey) *tcell.EventKey
 
+	// An optional capture function set by the primitive containing this box
+	// (e.g. a Form), which receives key events after the input capture
+	// function.
+	containerCapture func(event *tcell.EventKey) *tcell.EventKey
+
 	// An optional function which is called before the box is drawn.
 	draw func(screen tcell.Screen, x, y, width, height int) (int, int, int, int)
 
@@ -220,15 +228,26 @@ func (b *Box) GetDrawFunc() func(screen tcell.Screen, x, y, width, height int) (
 // This is only meant to be used by subclassing primitives.
 func (b *Box) WrapInputHandler(inputHandler func(*tcell.EventKey, func(p Primitive))) func(*tcell.EventKey, func(p Primitive)) {
 	return func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		if b.inputCapture != nil {
-			event = b.inputCapture(event)
-		}
+		event = b.captureInput(event)
 		if event != nil && inputHandler != nil {
 			inputHandler(event, setFocus)
 		}
 	}
 }
 
+// captureInput passes a key event to the input capture function and then to
+// the capture function of the container, if any. It returns nil when the event
+// was consumed.
+func (b *Box) captureInput(event *tcell.EventKey) *tcell.EventKey {
+	if b.inputCapture != nil {
+		event = b.inputCapture(event)
+	}
+	if event != nil && b.containerCapture != nil {
+		event = b.containerCapture(event)
+	}
+	return event
+}
+
 // InputHandler returns nil.
 func (b *Box) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	b.l.RLock()
@@ -267,6 +286,22 @@ func (b *Box) GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey {
 	return b.inputCapture
 }
 
+// containerCapturer is implemented by primitives which allow the primitive
+// containing them to capture their key events.
+type containerCapturer interface {
+	setContainerCapture(capture func(event *tcell.EventKey) *tcell.EventKey)
+}
+
+// setContainerCapture installs a capture function on behalf of the primitive
+// containing this box. Unlike the function installed with SetInputCapture, it
+// is not replaced by the user. Providing a nil handler removes it.
+func (b *Box) setContainerCapture(capture func(event *tcell.EventKey) *tcell.EventKey) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.containerCapture = capture
+}
+
 // WrapMouseHandler wraps a mouse event handler (see MouseHandler()) with the
 // functionality to capture mouse events (see SetMouseCapture()) before passing
 // them on to the provided (default) event handler.
@@ -547,6 +582,25 @@ func (b *Box) HasFocus() bool {
 	return b.hasFocus
 }
 
+// SetCursorStyle sets the style of the cursor (block, underline or bar, steady
+// or blinking) shown by primitives which display a cursor while focused. The
+// style is only a hint: terminals which do not support the requested style
+// show their default cursor instead.
+func (b *Box) SetCursorStyle(style tcell.CursorStyle) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.cursorStyle = style
+}
+
+// GetCursorStyle returns the style of the cursor shown while focused.
+func (b *Box) GetCursorStyle() tcell.CursorStyle {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	return b.cursorStyle
+}
+
 // GetFocusable returns the item's Focusable.
 func (b *Box) GetFocusable() Focusable {
 	b.l.RLock()
@@ -19,6 +19,9 @@ type Button struct {
 	// The label color when the button is in focus.
 	labelColorFocused tcell.Color
 
+	// The label color when the button is disabled.
+	labelColorDisabled tcell.Color
+
 	// The background color when the button is in focus.
 	backgroundColorFocused tcell.Color
 
@@ -32,6 +35,16 @@ type Button struct {
 	// An optional rune which is drawn after the label when the button is focused.
 	cursorRune rune
 
+	// Whether the button is disabled.
+	disabled bool
+
+	// Colors which take precedence over the label color and the background
+	// color applied by a Form, or tcell.ColorDefault to use those colors.
+	styleLabelColor, styleBackgroundColor tcell.Color
+
+	// The text attributes of the label.
+	labelAttributes tcell.AttrMask
+
 	sync.RWMutex
 }
 
@@ -45,8 +58,11 @@ func NewButton(label string) *Button {
 		label:                  []byte(label),
 		labelColor:             Styles.PrimaryTextColor,
 		labelColorFocused:      Styles.PrimaryTextColor,
+		labelColorDisabled:     Styles.DisabledTextColor,
 		cursorRune:             Styles.ButtonCursorRune,
 		backgroundColorFocused: Styles.ContrastBackgroundColor,
+		styleLabelColor:        ColorUnset,
+		styleBackgroundColor:   ColorUnset,
 	}
 }
 
@@ -83,6 +99,15 @@ func (b *Button) SetLabelColorFocused(color tcell.Color) {
 	b.labelColorFocused = color
 }
 
+// SetLabelColorDisabled sets the color of the button text when the button is
+// disabled.
+func (b *Button) SetLabelColorDisabled(color tcell.Color) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.labelColorDisabled = color
+}
+
 // SetCursorRune sets the rune to show within the button when it is focused.
 func (b *Button) SetCursorRune(rune rune) {
 	b.Lock()
@@ -100,6 +125,24 @@ func (b *Button) SetBackgroundColorFocused(color tcell.Color) {
 	b.backgroundColorFocused = color
 }
 
+// SetDisabled sets whether the button is disabled. Disabled buttons are
+// dimmed, may not be selected by the user and are skipped when navigating
+// between the items of a Form.
+func (b *Button) SetDisabled(disabled bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.disabled = disabled
+}
+
+// IsDisabled returns whether the button is disabled.
+func (b *Button) IsDisabled() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.disabled
+}
+
 // SetSelectedFunc sets a handler which is called when the button was selected.
 func (b *Button) SetSelectedFunc(handler func()) {
 	b.Lock()
@@ -122,6 +165,34 @@ func (b *Button) SetBlurFunc(handler func(key tcell.Key)) {
 	b.blur = handler
 }
 
+// setStyle sets colors which take precedence over the colors applied by a
+// Form. ColorUnset keeps the Form's color.
+func (b *Button) setStyle(backgroundColor, labelColor tcell.Color) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.styleBackgroundColor, b.styleLabelColor = backgroundColor, labelColor
+}
+
+// setLabelAttributes sets the text attributes of the label.
+func (b *Button) setLabelAttributes(attributes tcell.AttrMask) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.labelAttributes = attributes
+}
+
+// activate calls the selected handler unless the button is disabled.
+func (b *Button) activate() {
+	b.RLock()
+	disabled, selected := b.disabled, b.selected
+	b.RUnlock()
+
+	if selected != nil && !disabled {
+		selected()
+	}
+}
+
 // Draw draws this primitive onto the screen.
 func (b *Button) Draw(screen tcell.Screen) {
 	if !b.GetVisible() {
@@ -134,6 +205,9 @@ func (b *Button) Draw(screen tcell.Screen) {
 	// Draw the box.
 	borderColor := b.borderColor
 	backgroundColor := b.backgroundColor
+	if b.styleBackgroundColor != ColorUnset {
+		b.backgroundColor = b.styleBackgroundColor
+	}
 	if b.focus.HasFocus() {
 		b.backgroundColor = b.backgroundColorFocused
 		b.borderColor = b.labelColorFocused
@@ -151,10 +225,16 @@ func (b *Button) Draw(screen tcell.Screen) {
 	if width > 0 && height > 0 {
 		y = y + height/2
 		labelColor := b.labelColor
+		if b.styleLabelColor != ColorUnset {
+			labelColor = b.styleLabelColor
+		}
 		if b.focus.HasFocus() {
 			labelColor = b.labelColorFocused
 		}
-		_, pw := Print(screen, b.label, x, y, width, AlignCenter, labelColor)
+		if b.disabled {
+			labelColor = b.labelColorDisabled
+		}
+		_, pw := PrintStyle(screen, b.label, x, y, width, AlignCenter, tcell.StyleDefault.Foreground(labelColor).Attributes(b.labelAttributes))
 
 		// Draw cursor.
 		if b.focus.HasFocus() && b.cursorRune != 0 {
@@ -174,9 +254,7 @@ func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 	return b.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Process key event.
 		if HitShortcut(event, Keys.Select, Keys.Select2) {
-			if b.selected != nil {
-				b.selected()
-			}
+			b.activate()
 		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
 			if b.blur != nil {
 				b.blur(event.Key())
@@ -192,6 +270,10 @@ func (b *Button) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 			return false, nil
 		}
 
+		if b.IsDisabled() {
+			return false, nil
+		}
+
 		// Process mouse event.
 		if action == MouseLeftClick {
 			setFocus(b)
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -39,4 +41,17 @@ func TestButton(t *testing.T) {
 	}
 
 	b.Draw(app.screen)
+
+	// Disabled
+
+	b.SetRect(0, 0, 20, 1)
+	b.SetDisabled(true)
+	b.SetLabelColorDisabled(tcell.ColorRed)
+	b.Draw(app.screen)
+	x, _, _, _ := b.GetInnerRect()
+	x += (20 - len(testButtonLabelA)) / 2
+	_, _, style, _ := app.screen.GetContent(x, 0)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorRed {
+		t.Errorf("failed to draw disabled Button: incorrect label color: expected %v, got %v", tcell.ColorRed, fg)
+	}
 }
@@ -1,11 +1,31 @@
 package cview
 
 import (
+	"encoding/json"
+	"strconv"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// CheckBoxState represents the state of a CheckBox.
+type CheckBoxState int
+
+// CheckBox states. CheckBoxIndeterminate is only used by tri-state checkboxes.
+const (
+	CheckBoxUnchecked CheckBoxState = iota
+	CheckBoxChecked
+	CheckBoxIndeterminate
+)
+
+// checkBoxMarshaledState is the state of a CheckBox as persisted by
+// MarshalState.
+type checkBoxMarshaledState struct {
+	Checked       bool `json:"checked"`
+	Indeterminate bool `json:"indeterminate,omitempty"`
+	StateIndex    int  `json:"stateIndex,omitempty"`
+}
+
 // CheckBox implements a simple box for boolean values which can be checked and
 // unchecked.
 type CheckBox struct {
@@ -14,22 +34,62 @@ type CheckBox struct {
 	// Whether or not this box is checked.
 	checked bool
 
+	// Whether or not this box is disabled. Disabled boxes are dimmed, may not
+	// be toggled and are skipped when navigating a Form.
+	disabled bool
+
+	// Whether or not this box may be set to the indeterminate state by the
+	// user.
+	triState bool
+
+	// Whether or not this box is in the indeterminate state. When true, checked
+	// is false.
+	indeterminate bool
+
+	// The optional values which may be selected using the left and right arrow
+	// keys. When set, the current value is shown in place of the checked rune.
+	states []string
+
+	// The index of the currently selected value within states. The box is
+	// checked when any value other than the first (default) value is selected.
+	stateIndex int
+
 	// The text to be displayed before the checkbox.
 	label []byte
 
 	// The text to be displayed after the checkbox.
 	message []byte
 
+	// Whether or not the message is word-wrapped to the available width.
+	messageWrap bool
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
 
+	// The text drawn after the label, e.g. a colon.
+	labelSuffix string
+
+	// Whether the label suffix was set via SetLabelSuffix. The suffix of a
+	// form is then ignored.
+	labelSuffixSet bool
+
+	// Whether or not the label is right-aligned within the label area so that
+	// trailing colons line up.
+	labelColonAlign bool
+
+	// Where the label is drawn relative to the checkbox.
+	labelPosition LabelPosition
+
 	// The label color.
 	labelColor tcell.Color
 
 	// The label color when focused.
 	labelColorFocused tcell.Color
 
+	// The label color when disabled.
+	labelColorDisabled tcell.Color
+
 	// The background color of the input area.
 	fieldBackgroundColor tcell.Color
 
@@ -42,10 +102,24 @@ type CheckBox struct {
 	// The text color of the input area when focused.
 	fieldTextColorFocused tcell.Color
 
+	// The text color of the input area when disabled.
+	fieldTextColorDisabled tcell.Color
+
 	// An optional function which is called when the user changes the checked
 	// state of this checkbox.
 	changed func(checked bool)
 
+	// An optional function which is called when the user selects another value
+	// (see SetStates).
+	statesChanged func(index int, state string)
+
+	// An optional function which is called when the user changes the state of
+	// this checkbox.
+	stateChanged func(state CheckBoxState)
+
+	// The group of checkboxes of which at most one may be checked, or nil.
+	group *CheckBoxGroup
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -55,9 +129,33 @@ type CheckBox struct {
 	// this form item.
 	finished func(tcell.Key)
 
+	// A callback function set by the Form class and called when the value of
+	// this form item changes.
+	formChanged func()
+
 	// The rune to show when the checkbox is checked
 	checkedRune rune
 
+	// The rune to show when the checkbox is in the indeterminate state
+	indeterminateRune rune
+
+	// The rune to show when the checkbox is unchecked
+	uncheckedRune rune
+
+	// The rune to show when the checkbox is checked and focused. A value of 0
+	// uses the checked rune.
+	focusedRune rune
+
+	// The rune to show when the checkbox is unchecked and focused. A value of 0
+	// uses the unchecked rune.
+	focusedUncheckedRune rune
+
+	// The color of the checked rune. ColorUnset uses the field text color.
+	checkedColor tcell.Color
+
+	// The color of the unchecked rune. ColorUnset uses the field text color.
+	uncheckedColor tcell.Color
+
 	// An optional rune to show within the checkbox when it is focused
 	cursorRune rune
 
@@ -73,18 +171,302 @@ func NewCheckBox() *CheckBox {
 		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
 		fieldTextColor:              Styles.PrimaryTextColor,
 		checkedRune:                 Styles.CheckBoxCheckedRune,
+		indeterminateRune:           Styles.CheckBoxIndeterminateRune,
+		uncheckedRune:               ' ',
+		checkedColor:                ColorUnset,
+		uncheckedColor:              ColorUnset,
 		cursorRune:                  Styles.CheckBoxCursorRune,
 		labelColorFocused:           ColorUnset,
 		fieldTextColorFocused:       ColorUnset,
+		labelColorDisabled:          Styles.DisabledTextColor,
+		fieldTextColorDisabled:      Styles.DisabledTextColor,
 	}
 }
 
-// SetChecked sets the state of the checkbox.
+// SetChecked sets the state of the checkbox. When the checkbox is checked and
+// belongs to a group, the other checkboxes of the group are unchecked. When
+// values are set via SetStates, checking the checkbox selects the second value
+// unless another value than the default value is selected already, and
+// unchecking it selects the default value. The changed handlers of the
+// checkbox are not called (see SetCheckedWithCallback).
 func (c *CheckBox) SetChecked(checked bool) {
+	c.setState(checkBoxState(checked), -1, false)
+}
+
+// SetCheckedWithCallback sets the state of the checkbox like SetChecked, and
+// calls the changed handlers when the state differs from the previous state.
+func (c *CheckBox) SetCheckedWithCallback(checked bool) {
+	c.setState(checkBoxState(checked), -1, true)
+}
+
+// checkBoxState returns CheckBoxChecked when checked is true and
+// CheckBoxUnchecked otherwise.
+func checkBoxState(checked bool) CheckBoxState {
+	if checked {
+		return CheckBoxChecked
+	}
+	return CheckBoxUnchecked
+}
+
+// setState sets the state of the checkbox and, when values are set via
+// SetStates, the index of the selected value. A negative index selects a value
+// matching the state. The index is ignored when no values are set. When
+// callback is true, the changed handlers are called if the state or the
+// selected value differs from the previous one.
+func (c *CheckBox) setState(state CheckBoxState, index int, callback bool) {
+	c.Lock()
+	previousState, previousIndex := c.state(), c.stateIndex
+	if len(c.states) > 0 {
+		// The indeterminate state may not be shown in place of a value.
+		if index < 0 {
+			index = 0
+			if state == CheckBoxChecked {
+				index = c.stateIndex
+				if index == 0 && len(c.states) > 1 {
+					index = 1
+				}
+			}
+		}
+		if index >= len(c.states) {
+			c.Unlock()
+			return
+		}
+		c.stateIndex = index
+		c.checked = index != 0
+		c.indeterminate = false
+	} else {
+		c.checked = state == CheckBoxChecked
+		c.indeterminate = state == CheckBoxIndeterminate
+	}
+	state, index = c.state(), c.stateIndex
+	var value string
+	if len(c.states) > 0 {
+		value = c.states[index]
+	}
+	checked, group := c.checked, c.group
+	changed, stateChanged, statesChanged, formChanged := c.changed, c.stateChanged, c.statesChanged, c.formChanged
+	c.Unlock()
+
+	if group != nil {
+		group.update(c, checked)
+	}
+	if !callback || state == previousState && index == previousIndex {
+		return
+	}
+	if index != previousIndex && statesChanged != nil {
+		statesChanged(index, value)
+	}
+	if state != previousState && stateChanged != nil {
+		stateChanged(state)
+	}
+	if changed != nil {
+		changed(checked)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// SetGroup adds the checkbox to a group of checkboxes of which at most one may
+// be checked. Checking the checkbox unchecks the other checkboxes of the
+// group. Pass nil to remove the checkbox from its group.
+func (c *CheckBox) SetGroup(group *CheckBoxGroup) {
+	c.Lock()
+	previous := c.group
+	c.group = group
+	checked := c.checked
+	c.Unlock()
+
+	if previous != nil {
+		previous.remove(c)
+	}
+	if group != nil {
+		group.add(c)
+		if checked {
+			group.update(c, true)
+		}
+	}
+}
+
+// uncheck unchecks the checkbox on behalf of its group and calls the changed
+// handlers.
+func (c *CheckBox) uncheck() {
+	c.Lock()
+	if !c.checked {
+		c.Unlock()
+		return
+	}
+	c.checked = false
+	c.indeterminate = false
+	if len(c.states) > 0 {
+		c.stateIndex = 0
+	}
+	state := c.state()
+	changed, stateChanged, formChanged := c.changed, c.stateChanged, c.formChanged
+	c.Unlock()
+
+	if stateChanged != nil {
+		stateChanged(state)
+	}
+	if changed != nil {
+		changed(false)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// SetDisabled sets whether the checkbox is disabled. Disabled checkboxes are
+// dimmed, may not be toggled by the user and are skipped when navigating
+// between the items of a Form.
+func (c *CheckBox) SetDisabled(disabled bool) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.checked = checked
+	c.disabled = disabled
+}
+
+// IsDisabled returns whether the checkbox is disabled.
+func (c *CheckBox) IsDisabled() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.disabled
+}
+
+// SetTriState sets whether the user may set the checkbox to the indeterminate
+// state. When enabled, toggling the checkbox cycles from unchecked to checked
+// to indeterminate.
+func (c *CheckBox) SetTriState(triState bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.triState = triState
+}
+
+// SetState sets the state of the checkbox. The indeterminate state may be set
+// even when the checkbox is not a tri-state checkbox, but not when values are
+// set via SetStates, in which case the default value is selected instead (see
+// SetChecked). When the checkbox is checked and belongs to a group, the other
+// checkboxes of the group are unchecked.
+func (c *CheckBox) SetState(state CheckBoxState) {
+	c.setState(state, -1, false)
+}
+
+// SetStateWithCallback sets the state of the checkbox like SetState, and calls
+// the changed handlers when the state differs from the previous state.
+func (c *CheckBox) SetStateWithCallback(state CheckBoxState) {
+	c.setState(state, -1, true)
+}
+
+// GetState returns the state of the checkbox.
+func (c *CheckBox) GetState() CheckBoxState {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.state()
+}
+
+// MarshalState returns the state of the checkbox encoded as JSON. This
+// implements StateMarshaler.
+func (c *CheckBox) MarshalState() ([]byte, error) {
+	c.RLock()
+	state := checkBoxMarshaledState{
+		Checked:       c.checked,
+		Indeterminate: c.indeterminate,
+		StateIndex:    c.stateIndex,
+	}
+	c.RUnlock()
+
+	return json.Marshal(state)
+}
+
+// UnmarshalState restores the state of the checkbox, including the selected
+// value (see SetStates), from JSON returned by MarshalState. The changed
+// handlers are called when the state changes as a result (see
+// SetStateWithCallback). This implements StateMarshaler.
+func (c *CheckBox) UnmarshalState(data []byte) error {
+	var state checkBoxMarshaledState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	c.RLock()
+	hasStates := len(c.states) > 0
+	c.RUnlock()
+
+	if hasStates {
+		c.SetStateIndexWithCallback(state.StateIndex)
+	} else if state.Indeterminate {
+		c.SetStateWithCallback(CheckBoxIndeterminate)
+	} else {
+		c.SetCheckedWithCallback(state.Checked)
+	}
+	return nil
+}
+
+// state returns the state of the checkbox. The caller must hold the lock.
+func (c *CheckBox) state() CheckBoxState {
+	if c.indeterminate {
+		return CheckBoxIndeterminate
+	} else if c.checked {
+		return CheckBoxChecked
+	}
+	return CheckBoxUnchecked
+}
+
+// SetStates sets the values which may be selected using the left and right
+// arrow keys while the checkbox is focused, turning the checkbox into a compact
+// inline selector. The first value is the default value, which is selected
+// when Space or Enter is pressed. The current value is shown within the
+// checkbox in place of the checked rune. Pass nil to restore the regular
+// checkbox behavior.
+func (c *CheckBox) SetStates(states []string) {
+	c.Lock()
+	c.states = states
+	c.stateIndex = 0
+	c.checked = false
+	c.indeterminate = false
+	group := c.group
+	c.Unlock()
+
+	if group != nil {
+		group.update(c, false)
+	}
+}
+
+// SetStateIndex sets the index of the selected value (see SetStates). When a
+// value other than the default value is selected and the checkbox belongs to a
+// group, the other checkboxes of the group are unchecked.
+func (c *CheckBox) SetStateIndex(index int) {
+	c.RLock()
+	valid := index >= 0 && index < len(c.states)
+	c.RUnlock()
+
+	if valid {
+		c.setState(checkBoxState(index != 0), index, false)
+	}
+}
+
+// SetStateIndexWithCallback sets the index of the selected value like
+// SetStateIndex, and calls the changed handlers when the selected value
+// differs from the previous value.
+func (c *CheckBox) SetStateIndexWithCallback(index int) {
+	c.RLock()
+	valid := index >= 0 && index < len(c.states)
+	c.RUnlock()
+
+	if valid {
+		c.setState(checkBoxState(index != 0), index, true)
+	}
+}
+
+// GetStateIndex returns the index of the selected value (see SetStates).
+func (c *CheckBox) GetStateIndex() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.stateIndex
 }
 
 // SetCheckedRune sets the rune to show when the checkbox is checked.
@@ -95,6 +477,59 @@ func (c *CheckBox) SetCheckedRune(rune rune) {
 	c.checkedRune = rune
 }
 
+// SetUncheckedRune sets the rune to show when the checkbox is unchecked.
+func (c *CheckBox) SetUncheckedRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.uncheckedRune = rune
+}
+
+// SetFocusedRune sets the rune to show when the checkbox is checked and
+// focused. Set to 0 to use the rune set via SetCheckedRune.
+func (c *CheckBox) SetFocusedRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.focusedRune = rune
+}
+
+// SetFocusedUncheckedRune sets the rune to show when the checkbox is unchecked
+// and focused. Set to 0 to use the rune set via SetUncheckedRune.
+func (c *CheckBox) SetFocusedUncheckedRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.focusedUncheckedRune = rune
+}
+
+// SetCheckedColor sets the color of the rune shown when the checkbox is
+// checked. Set to ColorUnset to use the field text color.
+func (c *CheckBox) SetCheckedColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.checkedColor = color
+}
+
+// SetUncheckedColor sets the color of the rune shown when the checkbox is
+// unchecked. Set to ColorUnset to use the field text color.
+func (c *CheckBox) SetUncheckedColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.uncheckedColor = color
+}
+
+// SetIndeterminateRune sets the rune to show when the checkbox is in the
+// indeterminate state.
+func (c *CheckBox) SetIndeterminateRune(rune rune) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.indeterminateRune = rune
+}
+
 // SetCursorRune sets the rune to show within the checkbox when it is focused.
 func (c *CheckBox) SetCursorRune(rune rune) {
 	c.Lock()
@@ -111,7 +546,67 @@ func (c *CheckBox) IsChecked() bool {
 	return c.checked
 }
 
-// SetLabel sets the text to be displayed before the input area.
+// checkBoxIndeterminateValue is the field value of a checkbox in the
+// indeterminate state.
+const checkBoxIndeterminateValue = "indeterminate"
+
+// GetFieldValue returns the state of the checkbox: "true" when it is checked,
+// "false" when it is unchecked and "indeterminate" when it is in the
+// indeterminate state. When values are set via SetStates, the selected value
+// is returned instead. This implements FormValuer.
+func (c *CheckBox) GetFieldValue() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.states) > 0 {
+		return c.states[c.stateIndex]
+	} else if c.indeterminate {
+		return checkBoxIndeterminateValue
+	}
+	return strconv.FormatBool(c.checked)
+}
+
+// SetFieldValue sets the state of the checkbox from a value returned by
+// GetFieldValue (see SetState and SetStateIndex). Other values accepted by
+// strconv.ParseBool are accepted as well when no values are set via SetStates.
+// ErrInvalidFieldValue is returned for any other value. This implements
+// FormValuer.
+func (c *CheckBox) SetFieldValue(value string) error {
+	state, index, err := c.parseFieldValue(value)
+	if err != nil {
+		return err
+	}
+	c.setState(state, index, false)
+	return nil
+}
+
+// parseFieldValue returns the state and the index of the selected value (see
+// SetStates) encoded by a value returned by GetFieldValue. The index is -1
+// when no values are set.
+func (c *CheckBox) parseFieldValue(value string) (state CheckBoxState, index int, err error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if len(c.states) > 0 {
+		for index, state := range c.states {
+			if state == value {
+				return checkBoxState(index != 0), index, nil
+			}
+		}
+		return CheckBoxUnchecked, -1, ErrInvalidFieldValue
+	} else if value == checkBoxIndeterminateValue {
+		return CheckBoxIndeterminate, -1, nil
+	}
+
+	checked, err := strconv.ParseBool(value)
+	if err != nil {
+		return CheckBoxUnchecked, -1, ErrInvalidFieldValue
+	}
+	return checkBoxState(checked), -1, nil
+}
+
+// SetLabel sets the text to be displayed before the input area. The label may
+// contain color tags.
 func (c *CheckBox) SetLabel(label string) {
 	c.Lock()
 	defer c.Unlock()
@@ -127,7 +622,8 @@ func (c *CheckBox) GetLabel() string {
 	return string(c.label)
 }
 
-// SetMessage sets the text to be displayed after the checkbox
+// SetMessage sets the text to be displayed after the checkbox. The message may
+// contain color tags.
 func (c *CheckBox) SetMessage(message string) {
 	c.Lock()
 	defer c.Unlock()
@@ -143,6 +639,17 @@ func (c *CheckBox) GetMessage() string {
 	return string(c.message)
 }
 
+// SetMessageWrap sets a flag which determines whether the message is
+// word-wrapped to the width available after the checkbox. Wrapped lines are
+// drawn below the first line and are included in the height returned by
+// GetFieldHeight.
+func (c *CheckBox) SetMessageWrap(wrap bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.messageWrap = wrap
+}
+
 // SetLabelWidth sets the screen width of the label. A value of 0 will cause the
 // primitive to use the width of the label string.
 func (c *CheckBox) SetLabelWidth(width int) {
@@ -152,6 +659,65 @@ func (c *CheckBox) SetLabelWidth(width int) {
 	c.labelWidth = width
 }
 
+// SetLabelSuffix sets a text which is drawn after the label, e.g. ":". It
+// overrides the label suffix of the form the checkbox is added to.
+func (c *CheckBox) SetLabelSuffix(suffix string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelSuffix = suffix
+	c.labelSuffixSet = true
+}
+
+// setFormLabelSuffix sets the label suffix of the form this checkbox belongs
+// to. It is ignored when a suffix was set via SetLabelSuffix.
+func (c *CheckBox) setFormLabelSuffix(suffix string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.labelSuffixSet {
+		c.labelSuffix = suffix
+	}
+}
+
+// getLabelSuffix returns the text drawn after the label.
+func (c *CheckBox) getLabelSuffix() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.labelSuffix
+}
+
+// SetLabelPosition sets where the label is drawn. When set to LabelAbove, the
+// label is drawn on its own line and the checkbox below it. The label width
+// is then ignored.
+func (c *CheckBox) SetLabelPosition(position LabelPosition) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelPosition = position
+}
+
+// GetLabelPosition returns where the label is drawn.
+func (c *CheckBox) GetLabelPosition() LabelPosition {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.labelPosition
+}
+
+// SetLabelColonAlign sets a flag which determines whether the label is
+// right-aligned within the label area (see SetLabelWidth), leaving one cell of
+// space before the checkbox. When the labels of neighboring form items end
+// with a colon, this lines up the colons. This has no effect when the label
+// width is 0.
+func (c *CheckBox) SetLabelColonAlign(align bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelColonAlign = align
+}
+
 // SetLabelColor sets the color of the label.
 func (c *CheckBox) SetLabelColor(color tcell.Color) {
 	c.Lock()
@@ -168,6 +734,14 @@ func (c *CheckBox) SetLabelColorFocused(color tcell.Color) {
 	c.labelColorFocused = color
 }
 
+// SetLabelColorDisabled sets the color of the label when disabled.
+func (c *CheckBox) SetLabelColorDisabled(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.labelColorDisabled = color
+}
+
 // SetFieldBackgroundColor sets the background color of the input area.
 func (c *CheckBox) SetFieldBackgroundColor(color tcell.Color) {
 	c.Lock()
@@ -200,9 +774,28 @@ func (c *CheckBox) SetFieldTextColorFocused(color tcell.Color) {
 	c.fieldTextColorFocused = color
 }
 
+// SetFieldTextColorDisabled sets the text color of the input area when
+// disabled.
+func (c *CheckBox) SetFieldTextColorDisabled(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.fieldTextColorDisabled = color
+}
+
 // GetFieldHeight returns the height of the field.
 func (c *CheckBox) GetFieldHeight() int {
-	return 1
+	c.RLock()
+	defer c.RUnlock()
+
+	height := 1
+	if lines := c.messageLines(); len(lines) > 1 {
+		height = len(lines)
+	}
+	if c.labelPosition == LabelAbove {
+		height++
+	}
+	return height
 }
 
 // GetFieldWidth returns this primitive's field width.
@@ -210,11 +803,68 @@ func (c *CheckBox) GetFieldWidth() int {
 	c.RLock()
 	defer c.RUnlock()
 
+	boxWidth := c.boxWidth()
 	if len(c.message) == 0 {
-		return 1
+		return boxWidth - 2
+	}
+
+	if c.messageWrap {
+		var maxWidth int
+		for _, line := range c.messageLines() {
+			if lineWidth := TaggedTextWidth(line); lineWidth > maxWidth {
+				maxWidth = lineWidth
+			}
+		}
+		return boxWidth - 1 + maxWidth
 	}
 
-	return 2 + len(c.message)
+	return boxWidth - 1 + TaggedTextWidth(c.message)
+}
+
+// boxWidth returns the screen width of the checkbox, which is wider when it
+// shows one of multiple values (see SetStates). The caller must hold the lock.
+func (c *CheckBox) boxWidth() int {
+	var stateWidth int
+	for _, state := range c.states {
+		if w := TaggedStringWidth(state); w > stateWidth {
+			stateWidth = w
+		}
+	}
+	if stateWidth < 1 {
+		stateWidth = 1
+	}
+	return stateWidth + 2
+}
+
+// messageLines returns the lines of the message as they are drawn. When the
+// message is wrapped, the width available after the checkbox as determined by
+// the current size of the primitive is used. The caller must hold the lock.
+func (c *CheckBox) messageLines() [][]byte {
+	if len(c.message) == 0 {
+		return nil
+	}
+	if !c.messageWrap {
+		return [][]byte{c.message}
+	}
+
+	_, _, width, _ := c.GetInnerRect()
+	labelWidth := c.labelWidth
+	if labelWidth == 0 {
+		labelWidth = TaggedTextWidth(suffixedLabel(c.label, c.labelSuffix))
+	}
+	if c.labelPosition == LabelAbove {
+		labelWidth = 0
+	}
+	messageWidth := width - labelWidth - c.boxWidth() - 1
+	if messageWidth < 1 {
+		return [][]byte{c.message}
+	}
+
+	var lines [][]byte
+	for _, line := range WordWrap(string(c.message), messageWidth) {
+		lines = append(lines, []byte(line))
+	}
+	return lines
 }
 
 // SetChangedFunc sets a handler which is called when the checked state of this
@@ -227,6 +877,28 @@ func (c *CheckBox) SetChangedFunc(handler func(checked bool)) {
 	c.changed = handler
 }
 
+// SetStateChangedFunc sets a handler which is called when the state of this
+// checkbox was changed by the user. Unlike the handler set via SetChangedFunc,
+// which is also called, the handler function receives the indeterminate state
+// of tri-state checkboxes.
+func (c *CheckBox) SetStateChangedFunc(handler func(state CheckBoxState)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.stateChanged = handler
+}
+
+// SetStatesChangedFunc sets a handler which is called when the user selects
+// another value (see SetStates). The handler function receives the index of
+// the new value and the value itself. The handler set via SetChangedFunc is
+// also called.
+func (c *CheckBox) SetStatesChangedFunc(handler func(index int, state string)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.statesChanged = handler
+}
+
 // SetDoneFunc sets a handler which is called when the user is done using the
 // checkbox. The callback function is provided with the key that was pressed,
 // which is one of the following:
@@ -249,6 +921,15 @@ func (c *CheckBox) SetFinishedFunc(handler func(key tcell.Key)) {
 	c.finished = handler
 }
 
+// setFormChangedFunc sets a callback invoked when the value of this form item
+// changes.
+func (c *CheckBox) setFormChangedFunc(handler func()) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.formChanged = handler
+}
+
 // Draw draws this primitive onto the screen.
 func (c *CheckBox) Draw(screen tcell.Screen) {
 	if !c.GetVisible() {
@@ -277,24 +958,37 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 			fieldTextColor = c.fieldTextColorFocused
 		}
 	}
+	if c.disabled {
+		labelColor = c.labelColorDisabled
+		fieldTextColor = c.fieldTextColorDisabled
+	}
 
 	// Prepare
 	x, y, width, height := c.GetInnerRect()
 	rightLimit := x + width
+	bottomLimit := y + height
 	if height < 1 || rightLimit <= x {
 		return
 	}
 
 	// Draw label.
-	if c.labelWidth > 0 {
+	label := suffixedLabel(c.label, c.labelSuffix)
+	if c.labelPosition == LabelAbove {
+		Print(screen, label, x, y, width, AlignLeft, labelColor)
+		y++
+	} else if c.labelWidth > 0 {
 		labelWidth := c.labelWidth
 		if labelWidth > rightLimit-x {
 			labelWidth = rightLimit - x
 		}
-		Print(screen, c.label, x, y, labelWidth, AlignLeft, labelColor)
+		if c.labelColonAlign && labelWidth > 1 {
+			Print(screen, label, x, y, labelWidth-1, AlignRight, labelColor)
+		} else {
+			Print(screen, label, x, y, labelWidth, AlignLeft, labelColor)
+		}
 		x += labelWidth
 	} else {
-		_, drawnWidth := Print(screen, c.label, x, y, rightLimit-x, AlignLeft, labelColor)
+		_, drawnWidth := Print(screen, label, x, y, rightLimit-x, AlignLeft, labelColor)
 		x += drawnWidth
 	}
 
@@ -302,32 +996,73 @@ func (c *CheckBox) Draw(screen tcell.Screen) {
 	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor).Foreground(fieldTextColor)
 
 	checkedRune := c.checkedRune
-	if !c.checked {
-		checkedRune = ' '
+	checkedStyle := fieldStyle
+	if c.indeterminate {
+		checkedRune = c.indeterminateRune
+	} else if c.checked {
+		if hasFocus && c.focusedRune != 0 {
+			checkedRune = c.focusedRune
+		}
+		if c.checkedColor != ColorUnset && !c.disabled {
+			checkedStyle = fieldStyle.Foreground(c.checkedColor)
+		}
+	} else {
+		checkedRune = c.uncheckedRune
+		if hasFocus && c.focusedUncheckedRune != 0 {
+			checkedRune = c.focusedUncheckedRune
+		}
+		if c.uncheckedColor != ColorUnset && !c.disabled {
+			checkedStyle = fieldStyle.Foreground(c.uncheckedColor)
+		}
 	}
 	rightRune := ' '
 	if c.cursorRune != 0 && hasFocus {
 		rightRune = c.cursorRune
 	}
-	screen.SetContent(x, y, ' ', nil, fieldStyle)
-	screen.SetContent(x+1, y, checkedRune, nil, fieldStyle)
-	screen.SetContent(x+2, y, rightRune, nil, fieldStyle)
+	boxWidth := c.boxWidth()
+	for index := 0; index < boxWidth; index++ {
+		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
+	}
+	if len(c.states) > 0 {
+		Print(screen, []byte(c.states[c.stateIndex]), x+1, y, boxWidth-2, AlignLeft, fieldTextColor)
+	} else {
+		screen.SetContent(x+1, y, checkedRune, nil, checkedStyle)
+	}
+	screen.SetContent(x+boxWidth-1, y, rightRune, nil, fieldStyle)
 
-	if len(c.message) > 0 {
-		Print(screen, c.message, x+4, y, len(c.message), AlignLeft, labelColor)
+	messageX := x + boxWidth + 1
+	if c.messageWrap {
+		for index, line := range c.messageLines() {
+			if y+index >= bottomLimit {
+				break
+			}
+			Print(screen, line, messageX, y+index, rightLimit-messageX, AlignLeft, labelColor)
+		}
+	} else if len(c.message) > 0 {
+		Print(screen, c.message, messageX, y, rightLimit-messageX, AlignLeft, labelColor)
 	}
 }
 
 // InputHandler returns the handler for this primitive.
 func (c *CheckBox) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		if HitShortcut(event, Keys.Select, Keys.Select2) {
-			c.Lock()
-			c.checked = !c.checked
-			c.Unlock()
-			if c.changed != nil {
-				c.changed(c.checked)
-			}
+		c.RLock()
+		hasStates := len(c.states) > 0
+		disabled := c.disabled
+		c.RUnlock()
+
+		if disabled && !HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+			return
+		}
+
+		if hasStates && HitShortcut(event, Keys.Select, Keys.Select2) {
+			c.selectState(0)
+		} else if hasStates && HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+			c.selectState(-1)
+		} else if hasStates && HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+			c.selectState(1)
+		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
+			c.toggle()
 		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
 			if c.done != nil {
 				c.done(event.Key())
@@ -348,12 +1083,31 @@ func (c *CheckBox) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			return false, nil
 		}
 
+		// Clicks on the label, the checkbox and the (possibly wrapped) message
+		// all toggle the checkbox.
+		c.RLock()
+		rows := len(c.messageLines())
+		hasStates := len(c.states) > 0
+		disabled := c.disabled
+		above := c.labelPosition == LabelAbove
+		c.RUnlock()
+		if disabled {
+			return false, nil
+		}
+		if rows < 1 {
+			rows = 1
+		}
+		if above {
+			rows++
+		}
+
 		// Process mouse event.
-		if action == MouseLeftClick && y == rectY {
+		if action == MouseLeftClick && y >= rectY && y < rectY+rows {
 			setFocus(c)
-			c.checked = !c.checked
-			if c.changed != nil {
-				c.changed(c.checked)
+			if hasStates {
+				c.selectState(1)
+			} else {
+				c.toggle()
 			}
 			consumed = true
 		}
@@ -361,3 +1115,50 @@ func (c *CheckBox) MouseHandler() func(action MouseAction, event *tcell.EventMou
 		return
 	})
 }
+
+// toggle advances the checkbox to its next state and calls the changed
+// handlers. Tri-state checkboxes cycle from unchecked to checked to
+// indeterminate.
+func (c *CheckBox) toggle() {
+	c.Lock()
+	switch {
+	case c.indeterminate:
+		c.indeterminate = false
+	case c.checked && c.triState:
+		c.checked = false
+		c.indeterminate = true
+	default:
+		c.checked = !c.checked
+	}
+	checked, state := c.checked, c.state()
+	changed, stateChanged, formChanged := c.changed, c.stateChanged, c.formChanged
+	group := c.group
+	c.Unlock()
+
+	if group != nil {
+		group.update(c, checked)
+	}
+	if stateChanged != nil {
+		stateChanged(state)
+	}
+	if changed != nil {
+		changed(checked)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// selectState selects another value (see SetStates) and calls the changed
+// handlers. A direction of 0 selects the default value. Otherwise the next
+// (1) or previous (-1) value is selected, wrapping around at either end.
+func (c *CheckBox) selectState(direction int) {
+	c.RLock()
+	index := 0
+	if direction != 0 {
+		index = (c.stateIndex + direction + len(c.states)) % len(c.states)
+	}
+	c.RUnlock()
+
+	c.setState(checkBoxState(index != 0), index, true)
+}
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -45,6 +47,138 @@ func TestCheckBox(t *testing.T) {
 		t.Errorf("failed to update CheckBox state: incorrect state: expected unchecked, got checked")
 	}
 
+	// Set checked with callback
+
+	var changed int
+	c.SetChangedFunc(func(checked bool) {
+		changed++
+	})
+
+	c.SetCheckedWithCallback(true)
+	c.SetCheckedWithCallback(true)
+	if !c.IsChecked() {
+		t.Errorf("failed to update CheckBox state: incorrect state: expected checked, got unchecked")
+	} else if changed != 1 {
+		t.Errorf("failed to call CheckBox changed handler: expected 1 call, got %d", changed)
+	}
+
+	c.SetCheckedWithCallback(false)
+	c.SetChangedFunc(nil)
+	if changed != 2 {
+		t.Errorf("failed to call CheckBox changed handler: expected 2 calls, got %d", changed)
+	}
+
+	// Tri-state
+
+	c.SetTriState(true)
+	for _, expected := range []CheckBoxState{CheckBoxChecked, CheckBoxIndeterminate, CheckBoxUnchecked} {
+		c.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+		if c.GetState() != expected {
+			t.Errorf("failed to toggle tri-state CheckBox: expected state %d, got %d", expected, c.GetState())
+		}
+	}
+	c.SetTriState(false)
+
+	// Marshal state
+
+	for _, state := range []CheckBoxState{CheckBoxChecked, CheckBoxIndeterminate, CheckBoxUnchecked} {
+		c.SetState(state)
+		data, err := c.MarshalState()
+		if err != nil {
+			t.Errorf("failed to marshal CheckBox state: %s", err)
+		}
+		c.SetState(CheckBoxChecked)
+		if state == CheckBoxChecked {
+			c.SetState(CheckBoxUnchecked)
+		}
+		if err := c.UnmarshalState(data); err != nil {
+			t.Errorf("failed to unmarshal CheckBox state: %s", err)
+		} else if c.GetState() != state {
+			t.Errorf("failed to restore CheckBox state: expected state %d, got %d", state, c.GetState())
+		}
+	}
+
+	// Cycle states
+
+	c.SetStates([]string{"Low", "Medium", "High"})
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), func(p Primitive) {})
+	if c.GetStateIndex() != 2 {
+		t.Errorf("failed to select previous CheckBox state: expected index 2, got %d", c.GetStateIndex())
+	} else if !c.IsChecked() {
+		t.Errorf("failed to select previous CheckBox state: expected checked, got unchecked")
+	}
+
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone), func(p Primitive) {})
+	if c.GetStateIndex() != 0 {
+		t.Errorf("failed to select next CheckBox state: expected index 0, got %d", c.GetStateIndex())
+	}
+
+	c.SetStateIndex(1)
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(p Primitive) {})
+	if c.GetStateIndex() != 0 {
+		t.Errorf("failed to reset CheckBox state: expected index 0, got %d", c.GetStateIndex())
+	}
+
+	// The selected value is persisted.
+
+	c.SetStateIndex(2)
+	data, err := c.MarshalState()
+	if err != nil {
+		t.Errorf("failed to marshal CheckBox state: %s", err)
+	}
+	c.SetStateIndex(1)
+	if err := c.UnmarshalState(data); err != nil {
+		t.Errorf("failed to unmarshal CheckBox state: %s", err)
+	} else if c.GetStateIndex() != 2 {
+		t.Errorf("failed to restore CheckBox state: expected index 2, got %d", c.GetStateIndex())
+	}
+	c.SetStateIndex(0)
+
+	// The selected value follows the checked state.
+
+	c.SetChecked(true)
+	if c.GetStateIndex() != 1 {
+		t.Errorf("failed to check CheckBox with states: expected index 1, got %d", c.GetStateIndex())
+	}
+	c.SetStateIndex(2)
+	c.SetChecked(true)
+	if c.GetStateIndex() != 2 {
+		t.Errorf("failed to keep selected CheckBox state: expected index 2, got %d", c.GetStateIndex())
+	}
+	c.SetChecked(false)
+	if c.GetStateIndex() != 0 || c.IsChecked() {
+		t.Errorf("failed to uncheck CheckBox with states: expected index 0 and unchecked, got %d and %t", c.GetStateIndex(), c.IsChecked())
+	}
+	c.SetStateIndex(2)
+	c.SetState(CheckBoxIndeterminate)
+	if c.GetStateIndex() != 0 || c.GetState() != CheckBoxUnchecked {
+		t.Errorf("failed to set indeterminate CheckBox with states: expected index 0 and state %d, got %d and %d", CheckBoxUnchecked, c.GetStateIndex(), c.GetState())
+	}
+
+	// Disable
+
+	c.SetDisabled(true)
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if c.GetState() != CheckBoxUnchecked {
+		t.Errorf("failed to disable CheckBox: expected state %d, got %d", CheckBoxUnchecked, c.GetState())
+	}
+	c.SetDisabled(false)
+
+	// Click label
+
+	c.SetStates(nil)
+	c.SetRect(0, 0, 40, 3)
+	c.SetMessage(testCheckBoxLabelA + " " + testCheckBoxLabelB)
+	c.SetMessageWrap(true)
+	c.SetChecked(false)
+	for _, y := range []int{0, 1} {
+		checked := c.IsChecked()
+		c.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, y, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+		if c.IsChecked() == checked {
+			t.Errorf("failed to toggle CheckBox by clicking row %d: expected checked %t, got %t", y, !checked, c.IsChecked())
+		}
+	}
+
 	// Draw
 
 	app, err := newTestApp(c)
@@ -53,4 +187,126 @@ func TestCheckBox(t *testing.T) {
 	}
 
 	c.Draw(app.screen)
+
+	// Tagged label and message
+
+	tagged := NewCheckBox()
+	tagged.SetLabel("[red]*[-]Name ")
+	tagged.SetMessage("Yes")
+	expectedWidth := tagged.GetFieldWidth()
+	tagged.SetMessage("[green]Yes")
+	if w := tagged.GetFieldWidth(); w != expectedWidth {
+		t.Errorf("failed to calculate CheckBox field width with tagged message: expected %d, got %d", expectedWidth, w)
+	}
+	tagged.SetRect(0, 0, 20, 1)
+	tagged.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(1, 0); r != 'N' {
+		t.Errorf("failed to draw tagged CheckBox label: expected N, got %c", r)
+	} else if r, _, _, _ := app.screen.GetContent(10, 0); r != 'Y' {
+		t.Errorf("failed to draw tagged CheckBox message: expected Y at 10, got %c", r)
+	}
+
+	// Disabled colors
+
+	tagged.SetDisabled(true)
+	tagged.SetLabelColorDisabled(tcell.ColorBlue)
+	tagged.SetFieldTextColorDisabled(tcell.ColorPurple)
+	tagged.Draw(app.screen)
+	_, _, style, _ := app.screen.GetContent(1, 0)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorBlue {
+		t.Errorf("failed to draw disabled CheckBox label: expected %v, got %v", tcell.ColorBlue, fg)
+	}
+
+	// Focused runes
+
+	f := NewCheckBox()
+	f.SetFocusedRune('x')
+	f.SetFocusedUncheckedRune('_')
+	f.SetRect(0, 0, 10, 1)
+	for _, test := range []struct {
+		checked, focused bool
+		expected         rune
+	}{
+		{false, false, ' '},
+		{false, true, '_'},
+		{true, false, Styles.CheckBoxCheckedRune},
+		{true, true, 'x'},
+	} {
+		f.SetChecked(test.checked)
+		if test.focused {
+			f.Focus(func(p Primitive) {})
+		} else {
+			f.Blur()
+		}
+		f.Draw(app.screen)
+		if r, _, _, _ := app.screen.GetContent(1, 0); r != test.expected {
+			t.Errorf("failed to draw CheckBox (checked %t, focused %t): expected rune %c, got %c", test.checked, test.focused, test.expected, r)
+		}
+	}
+}
+
+func TestCheckBoxDraw(t *testing.T) {
+	t.Parallel()
+
+	c := NewCheckBox()
+	c.SetLabel("Name:")
+	c.SetLabelWidth(10)
+
+	app, err := newTestApp(c)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	c.SetRect(0, 0, 20, 1)
+
+	// content returns the rune and the foreground color drawn in the given
+	// column.
+	content := func(x int) (rune, tcell.Color) {
+		ch, _, style, _ := app.screen.GetContent(x, 0)
+		fg, _, _ := style.Decompose()
+		return ch, fg
+	}
+
+	// Label colon alignment
+
+	c.Draw(app.screen)
+	if ch, _ := content(0); ch != 'N' {
+		t.Errorf("failed to draw CheckBox label: expected N in column 0, got %c", ch)
+	}
+
+	c.SetLabelColonAlign(true)
+	c.Draw(app.screen)
+	if ch, _ := content(4); ch != 'N' {
+		t.Errorf("failed to align CheckBox label colon: expected N in column 4, got %c", ch)
+	} else if ch, _ := content(8); ch != ':' {
+		t.Errorf("failed to align CheckBox label colon: expected : in column 8, got %c", ch)
+	} else if ch, _ := content(9); ch != ' ' {
+		t.Errorf("failed to separate aligned CheckBox label: expected space in column 9, got %c", ch)
+	}
+
+	// Runes and colors
+
+	c.SetUncheckedRune('-')
+	c.Draw(app.screen)
+	if ch, fg := content(11); ch != '-' || fg != Styles.PrimaryTextColor {
+		t.Errorf("failed to draw unchecked CheckBox: expected - in %v, got %c in %v", Styles.PrimaryTextColor, ch, fg)
+	}
+
+	c.SetCheckedColor(tcell.ColorGreen)
+	c.SetUncheckedColor(tcell.ColorRed)
+	c.Draw(app.screen)
+	if ch, fg := content(11); ch != '-' || fg != tcell.ColorRed {
+		t.Errorf("failed to apply CheckBox unchecked color: expected - in %v, got %c in %v", tcell.ColorRed, ch, fg)
+	}
+
+	c.SetChecked(true)
+	c.Draw(app.screen)
+	if ch, fg := content(11); ch != Styles.CheckBoxCheckedRune || fg != tcell.ColorGreen {
+		t.Errorf("failed to apply CheckBox checked color: expected %c in %v, got %c in %v", Styles.CheckBoxCheckedRune, tcell.ColorGreen, ch, fg)
+	}
+
+	c.SetCheckedColor(ColorUnset)
+	c.Draw(app.screen)
+	if _, fg := content(11); fg != Styles.PrimaryTextColor {
+		t.Errorf("failed to reset CheckBox checked color: expected %v, got %v", Styles.PrimaryTextColor, fg)
+	}
 }
@@ -0,0 +1,85 @@
+package cview
+
+import "sync"
+
+// CheckBoxGroup links CheckBoxes of which at most one may be checked. When a
+// CheckBox of the group is checked, the other CheckBoxes are unchecked and
+// their changed handlers are called. Add CheckBoxes to a group via
+// CheckBox.SetGroup.
+type CheckBoxGroup struct {
+	// The CheckBoxes of the group.
+	checkBoxes []*CheckBox
+
+	// The CheckBox which is checked, or nil.
+	selected *CheckBox
+
+	sync.RWMutex
+}
+
+// NewCheckBoxGroup returns a new, empty CheckBoxGroup.
+func NewCheckBoxGroup() *CheckBoxGroup {
+	return &CheckBoxGroup{}
+}
+
+// Selected returns the CheckBox which is checked, or nil when no CheckBox of
+// the group is checked.
+func (g *CheckBoxGroup) Selected() *CheckBox {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.selected
+}
+
+// add adds a CheckBox to the group.
+func (g *CheckBoxGroup) add(c *CheckBox) {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, checkBox := range g.checkBoxes {
+		if checkBox == c {
+			return
+		}
+	}
+	g.checkBoxes = append(g.checkBoxes, c)
+}
+
+// remove removes a CheckBox from the group.
+func (g *CheckBoxGroup) remove(c *CheckBox) {
+	g.Lock()
+	defer g.Unlock()
+
+	for index, checkBox := range g.checkBoxes {
+		if checkBox == c {
+			g.checkBoxes = append(g.checkBoxes[:index], g.checkBoxes[index+1:]...)
+			break
+		}
+	}
+	if g.selected == c {
+		g.selected = nil
+	}
+}
+
+// update records the new state of a CheckBox of the group and unchecks the
+// other CheckBoxes when it was checked.
+func (g *CheckBoxGroup) update(c *CheckBox, checked bool) {
+	g.Lock()
+	if !checked {
+		if g.selected == c {
+			g.selected = nil
+		}
+		g.Unlock()
+		return
+	}
+	g.selected = c
+	others := make([]*CheckBox, 0, len(g.checkBoxes))
+	for _, checkBox := range g.checkBoxes {
+		if checkBox != c {
+			others = append(others, checkBox)
+		}
+	}
+	g.Unlock()
+
+	for _, checkBox := range others {
+		checkBox.uncheck()
+	}
+}
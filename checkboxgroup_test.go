@@ -0,0 +1,82 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCheckBoxGroup(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	var uncheckedA int
+	g := NewCheckBoxGroup()
+	a := NewCheckBox()
+	a.SetChangedFunc(func(checked bool) {
+		if !checked {
+			uncheckedA++
+		}
+	})
+	b := NewCheckBox()
+	a.SetGroup(g)
+	b.SetGroup(g)
+	if g.Selected() != nil {
+		t.Errorf("failed to initialize CheckBoxGroup: expected no selected CheckBox")
+	}
+
+	// Check
+
+	a.SetChecked(true)
+	if g.Selected() != a {
+		t.Errorf("failed to check CheckBox: expected first CheckBox to be selected")
+	}
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if g.Selected() != b {
+		t.Errorf("failed to check CheckBox: expected second CheckBox to be selected")
+	} else if a.IsChecked() {
+		t.Errorf("failed to check CheckBox: expected first CheckBox to be unchecked")
+	} else if uncheckedA != 1 {
+		t.Errorf("failed to check CheckBox: expected changed handler of first CheckBox to be called once, got %d", uncheckedA)
+	}
+
+	// Uncheck
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if g.Selected() != nil {
+		t.Errorf("failed to uncheck CheckBox: expected no selected CheckBox")
+	}
+
+	// State
+
+	a.SetState(CheckBoxChecked)
+	b.SetState(CheckBoxChecked)
+	if g.Selected() != b || a.IsChecked() {
+		t.Errorf("failed to set CheckBox state: expected only second CheckBox to be checked")
+	}
+	b.SetState(CheckBoxIndeterminate)
+	if g.Selected() != nil {
+		t.Errorf("failed to set CheckBox state: expected no selected CheckBox")
+	}
+
+	a.SetStates([]string{"Off", "On"})
+	a.SetStateIndex(1)
+	if g.Selected() != a {
+		t.Errorf("failed to set CheckBox state index: expected first CheckBox to be selected")
+	}
+	a.SetStates(nil)
+	if g.Selected() != nil {
+		t.Errorf("failed to reset CheckBox states: expected no selected CheckBox")
+	}
+
+	// Remove
+
+	b.SetChecked(true)
+	b.SetGroup(nil)
+	a.SetChecked(true)
+	if !b.IsChecked() {
+		t.Errorf("failed to remove CheckBox from group: expected removed CheckBox to remain checked")
+	}
+}
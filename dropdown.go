@@ -111,6 +111,13 @@ type DropDown struct {
 	// the label text.
 	labelWidth int
 
+	// The text drawn after the label, e.g. a colon.
+	labelSuffix string
+
+	// Whether the label suffix was set via SetLabelSuffix. The suffix of a
+	// form is then ignored.
+	labelSuffixSet bool
+
 	// The screen width of the input area. A value of 0 means extend as much as
 	// possible.
 	fieldWidth int
@@ -124,6 +131,10 @@ type DropDown struct {
 	// this form item.
 	finished func(tcell.Key)
 
+	// A callback function set by the Form class and called when the value of
+	// this form item changes.
+	formChanged func()
+
 	// A callback function which is called when the user changes the drop-down's
 	// selection.
 	selected func(index int, option *DropDownOption)
@@ -230,6 +241,16 @@ func (d *DropDown) SetCurrentOption(index int) {
 	d.Lock()
 	defer d.Unlock()
 
+	previous := d.currentOption
+	defer func() {
+		if d.currentOption != previous && d.formChanged != nil {
+			formChanged := d.formChanged
+			d.Unlock()
+			formChanged()
+			d.Lock()
+		}
+	}()
+
 	if index >= 0 && index < len(d.options) {
 		d.currentOption = index
 		d.list.SetCurrentItem(index)
@@ -267,6 +288,37 @@ func (d *DropDown) GetCurrentOption() (int, *DropDownOption) {
 	return d.currentOption, option
 }
 
+// GetFieldValue returns the text of the selected option, or an empty string
+// when no option is selected. This implements FormValuer.
+func (d *DropDown) GetFieldValue() string {
+	_, option := d.GetCurrentOption()
+	if option == nil {
+		return ""
+	}
+	return option.GetText()
+}
+
+// SetFieldValue selects the first option with the given text (see
+// SetCurrentOption). ErrInvalidFieldValue is returned when there is no such
+// option. This implements FormValuer.
+func (d *DropDown) SetFieldValue(value string) error {
+	d.RLock()
+	optionIndex := -1
+	for index, option := range d.options {
+		if option.GetText() == value {
+			optionIndex = index
+			break
+		}
+	}
+	d.RUnlock()
+
+	if optionIndex < 0 {
+		return ErrInvalidFieldValue
+	}
+	d.SetCurrentOption(optionIndex)
+	return nil
+}
+
 // SetTextOptions sets the text to be placed before and after each drop-down
 // option (prefix/suffix), the text placed before and after the currently
 // selected option (currentPrefix/currentSuffix) as well as the text to be
@@ -311,6 +363,35 @@ func (d *DropDown) SetLabelWidth(width int) {
 	d.labelWidth = width
 }
 
+// SetLabelSuffix sets a text which is drawn after the label, e.g. ":". It
+// overrides the label suffix of the form the drop-down is added to.
+func (d *DropDown) SetLabelSuffix(suffix string) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.labelSuffix = suffix
+	d.labelSuffixSet = true
+}
+
+// setFormLabelSuffix sets the label suffix of the form this drop-down belongs
+// to. It is ignored when a suffix was set via SetLabelSuffix.
+func (d *DropDown) setFormLabelSuffix(suffix string) {
+	d.Lock()
+	defer d.Unlock()
+
+	if !d.labelSuffixSet {
+		d.labelSuffix = suffix
+	}
+}
+
+// getLabelSuffix returns the text drawn after the label.
+func (d *DropDown) getLabelSuffix() string {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.labelSuffix
+}
+
 // SetLabelColor sets the color of the label.
 func (d *DropDown) SetLabelColor(color tcell.Color) {
 	d.Lock()
@@ -535,6 +616,15 @@ func (d *DropDown) SetFinishedFunc(handler func(key tcell.Key)) {
 	d.finished = handler
 }
 
+// setFormChangedFunc sets a callback invoked when the value of this form item
+// changes.
+func (d *DropDown) setFormChangedFunc(handler func()) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.formChanged = handler
+}
+
 // Draw draws this primitive onto the screen.
 func (d *DropDown) Draw(screen tcell.Screen) {
 	d.Box.Draw(screen)
@@ -567,15 +657,16 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	}
 
 	// Draw label.
+	label := suffixedLabel([]byte(d.label), d.labelSuffix)
 	if d.labelWidth > 0 {
 		labelWidth := d.labelWidth
 		if labelWidth > rightLimit-x {
 			labelWidth = rightLimit - x
 		}
-		Print(screen, []byte(d.label), x, y, labelWidth, AlignLeft, labelColor)
+		Print(screen, label, x, y, labelWidth, AlignLeft, labelColor)
 		x += labelWidth
 	} else {
-		_, drawnWidth := Print(screen, []byte(d.label), x, y, rightLimit-x, AlignLeft, labelColor)
+		_, drawnWidth := Print(screen, label, x, y, rightLimit-x, AlignLeft, labelColor)
 		x += drawnWidth
 	}
 
@@ -742,6 +833,9 @@ func (d *DropDown) openList(setFocus func(Primitive)) {
 		if d.options[d.currentOption].selected != nil {
 			d.options[d.currentOption].selected(d.currentOption, d.options[d.currentOption])
 		}
+		if d.currentOption != optionBefore && d.formChanged != nil {
+			d.formChanged()
+		}
 	})
 	d.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyRune {
@@ -1,7 +1,10 @@
 package cview
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
+	"strconv"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
@@ -12,6 +15,60 @@ import (
 // horizontal layouts.
 var DefaultFormFieldWidth = 10
 
+// LabelPosition describes where the label of a form item is drawn.
+type LabelPosition int
+
+// Label positions.
+const (
+	LabelLeft  LabelPosition = iota // The label is drawn to the left of the field.
+	LabelAbove                      // The label is drawn on its own line above the field.
+)
+
+// labelPositioner is implemented by form items which support drawing their
+// label above the field.
+type labelPositioner interface {
+	GetLabelPosition() LabelPosition
+}
+
+// labelAbove returns whether the label of the given form item is drawn above
+// its field.
+func labelAbove(item FormItem) bool {
+	p, ok := item.(labelPositioner)
+	return ok && p.GetLabelPosition() == LabelAbove
+}
+
+// formChangeNotifier is implemented by form items which notify the form when
+// their value changes.
+type formChangeNotifier interface {
+	setFormChangedFunc(handler func())
+}
+
+// labelSuffixer is implemented by form items which draw a suffix after their
+// label.
+type labelSuffixer interface {
+	setFormLabelSuffix(suffix string)
+	getLabelSuffix() string
+}
+
+// suffixedLabel returns the label followed by the suffix. Empty labels are
+// returned unchanged.
+func suffixedLabel(label []byte, suffix string) []byte {
+	if len(label) == 0 || suffix == "" {
+		return label
+	}
+	return append(label[:len(label):len(label)], suffix...)
+}
+
+// formItemLabelWidth returns the screen width of the label of a form item,
+// including its label suffix.
+func formItemLabelWidth(item FormItem) int {
+	label := []byte(item.GetLabel())
+	if s, ok := item.(labelSuffixer); ok {
+		label = suffixedLabel(label, s.getLabelSuffix())
+	}
+	return TaggedTextWidth(label)
+}
+
 // FormItemAttributes is a set of attributes to be applied.
 type FormItemAttributes struct {
 	// The screen width of the label. A value of 0 will cause the primitive to
@@ -75,6 +132,68 @@ type FormItem interface {
 	SetFinishedFunc(func(key tcell.Key))
 }
 
+// FormValuer is implemented by form items whose value may be represented as a
+// string, e.g. to validate it (see Form.SetItemValidator) or to get and set
+// the values of all form items (see Form.GetValues and Form.SetValues). All
+// form items provided by this package implement this interface.
+type FormValuer interface {
+	// GetFieldValue returns the value of the form item as a string. The value
+	// is encoded as follows:
+	//
+	//   - InputField: The text.
+	//   - CheckBox: "true" when checked, "false" when unchecked and
+	//     "indeterminate" in the indeterminate state, or the selected value
+	//     when values are set via CheckBox.SetStates.
+	//   - DropDown: The text of the selected option, or "" if none.
+	//   - RadioGroup: The label of the selected option, or "" if none.
+	//   - Slider: The value as a decimal number.
+	GetFieldValue() string
+
+	// SetFieldValue sets the value of the form item from a string encoded as
+	// described in GetFieldValue. ErrInvalidFieldValue is returned when the
+	// value cannot be represented by the form item.
+	SetFieldValue(value string) error
+}
+
+// StateMarshaler is implemented by form items whose state may be persisted,
+// e.g. to save a draft of a form to disk. InputField and CheckBox implement
+// this interface.
+type StateMarshaler interface {
+	// MarshalState returns the state of the form item encoded as JSON.
+	MarshalState() ([]byte, error)
+
+	// UnmarshalState restores the state of the form item from JSON returned
+	// by MarshalState.
+	UnmarshalState(data []byte) error
+}
+
+// ErrInvalidFieldValue is returned by FormValuer.SetFieldValue when the value
+// cannot be represented by the form item.
+var ErrInvalidFieldValue = errors.New("invalid field value")
+
+// FormValidationError is returned by Form.Validate for each form item which
+// failed validation.
+type FormValidationError struct {
+	// The index of the form item.
+	Index int
+
+	// The label of the form item.
+	Label string
+
+	// The error returned by the validator of the form item.
+	Err error
+}
+
+// Error returns the label of the form item followed by the validation error.
+func (e *FormValidationError) Error() string {
+	return e.Label + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the validator of the form item.
+func (e *FormValidationError) Unwrap() error {
+	return e.Err
+}
+
 // Form allows you to combine multiple one-line form elements into a vertical
 // or horizontal layout. Form elements include types such as InputField or
 // CheckBox. These elements can be optionally followed by one or more buttons
@@ -106,6 +225,10 @@ type Form struct {
 	// Whether or not navigating the form will wrap around.
 	wrapAround bool
 
+	// The indices of the elements in the order in which they receive focus.
+	// Elements not included follow in their natural order.
+	tabOrder []int
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -136,9 +259,38 @@ type Form struct {
 	// The color of the button text when focused.
 	buttonTextColorFocused tcell.Color
 
+	// The background color of the row of the focused item or button.
+	focusHighlightColor tcell.Color
+
+	// The text drawn after the label of each item.
+	labelSuffix string
+
 	// An optional function which is called when the user hits Escape.
 	cancel func()
 
+	// An optional function which is called when the value of a form item
+	// changes.
+	changed func(item FormItem)
+
+	// An optional function which is called when the user submits the form.
+	submit func()
+
+	// The key which submits the form.
+	submitKey tcell.Key
+
+	// An optional function which is called when a form item reports Enter to
+	// the form. When it returns true, the focus is not moved.
+	enter func() bool
+
+	// The function which was last provided to shift the focus.
+	delegate func(p Primitive)
+
+	// Functions which validate the values of form items (see Validate).
+	validators map[FormItem]func(value string) error
+
+	// The values of the form items when SaveState was last called.
+	savedValues map[FormItem]string
+
 	sync.RWMutex
 }
 
@@ -160,6 +312,8 @@ func NewForm() *Form {
 		buttonTextColor:              Styles.PrimaryTextColor,
 		buttonTextColorFocused:       Styles.PrimaryTextColor,
 		labelColorFocused:            ColorUnset,
+		focusHighlightColor:          ColorUnset,
+		submitKey:                    tcell.KeyCtrlS,
 	}
 
 	f.focus = f
@@ -276,6 +430,32 @@ func (f *Form) SetButtonTextColorFocused(color tcell.Color) {
 	f.buttonTextColorFocused = color
 }
 
+// SetFocusHighlightColor sets the background color of a band drawn across the
+// full width of the form behind the focused item or button, making it easier
+// to spot. The background of the focused item itself is changed to this color
+// as well. Set to ColorUnset (the default) to disable the highlight.
+func (f *Form) SetFocusHighlightColor(color tcell.Color) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.focusHighlightColor = color
+}
+
+// SetLabelSuffix sets a text which is drawn after the label of each item, e.g.
+// ":", so that it does not need to be included in every label. Items which
+// have their own suffix set via SetLabelSuffix are not affected.
+func (f *Form) SetLabelSuffix(suffix string) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.labelSuffix = suffix
+	for _, item := range f.items {
+		if s, ok := item.(labelSuffixer); ok {
+			s.setFormLabelSuffix(suffix)
+		}
+	}
+}
+
 // SetFocus shifts the focus to the form element with the given index, counting
 // non-button items first and buttons last. Note that this index is only used
 // when the form itself receives focus.
@@ -292,6 +472,19 @@ func (f *Form) SetFocus(index int) {
 	}
 }
 
+// SetFocusByLabel shifts the focus to the first form element with the given
+// label. Buttons are not searched. It returns false when no such element
+// exists. As with SetFocus, the element only receives focus when the form
+// itself receives focus.
+func (f *Form) SetFocusByLabel(label string) bool {
+	index := f.GetFormItemIndex(label)
+	if index < 0 {
+		return false
+	}
+	f.SetFocus(index)
+	return true
+}
+
 // AddInputField adds an input field to the form. It has a label, an optional
 // initial value, a field width (a value of 0 extends it as far as possible),
 // an optional accept function to validate the item's value (set to nil to
@@ -308,7 +501,7 @@ func (f *Form) AddInputField(label, value string, fieldWidth int, accept func(te
 	inputField.SetAcceptanceFunc(accept)
 	inputField.SetChangedFunc(changed)
 
-	f.items = append(f.items, inputField)
+	f.addItem(inputField)
 }
 
 // AddPasswordField adds a password field to the form. This is similar to an
@@ -332,7 +525,7 @@ func (f *Form) AddPasswordField(label, value string, fieldWidth int, mask rune,
 	passwordField.SetMaskCharacter(mask)
 	passwordField.SetChangedFunc(changed)
 
-	f.items = append(f.items, passwordField)
+	f.addItem(passwordField)
 }
 
 // AddDropDownSimple adds a drop-down element to the form. It has a label, options,
@@ -348,7 +541,7 @@ func (f *Form) AddDropDownSimple(label string, initialOption int, selected func(
 	dd.SetOptionsSimple(selected, options...)
 	dd.SetCurrentOption(initialOption)
 
-	f.items = append(f.items, dd)
+	f.addItem(dd)
 }
 
 // AddDropDown adds a drop-down element to the form. It has a label, options,
@@ -364,7 +557,7 @@ func (f *Form) AddDropDown(label string, initialOption int, selected func(index
 	dd.SetOptions(selected, options...)
 	dd.SetCurrentOption(initialOption)
 
-	f.items = append(f.items, dd)
+	f.addItem(dd)
 }
 
 // AddCheckBox adds a checkbox to the form. It has a label, a message, an
@@ -380,7 +573,7 @@ func (f *Form) AddCheckBox(label string, message string, checked bool, changed f
 	c.SetChecked(checked)
 	c.SetChangedFunc(changed)
 
-	f.items = append(f.items, c)
+	f.addItem(c)
 }
 
 // AddSlider adds a slider to the form. It has a label, an initial value, a
@@ -398,7 +591,7 @@ func (f *Form) AddSlider(label string, current, max, increment int, changed func
 	s.SetIncrement(increment)
 	s.SetChangedFunc(changed)
 
-	f.items = append(f.items, s)
+	f.addItem(s)
 }
 
 // AddButton adds a new button to the form. The "selected" function is called
@@ -409,6 +602,7 @@ func (f *Form) AddButton(label string, selected func()) {
 
 	button := NewButton(label)
 	button.SetSelectedFunc(selected)
+	button.setContainerCapture(f.captureSubmitKey)
 	f.buttons = append(f.buttons, button)
 }
 
@@ -428,6 +622,7 @@ func (f *Form) RemoveButton(index int) {
 	f.Lock()
 	defer f.Unlock()
 
+	f.buttons[index].setContainerCapture(nil)
 	f.buttons = append(f.buttons[:index], f.buttons[index+1:]...)
 }
 
@@ -460,9 +655,13 @@ func (f *Form) Clear(includeButtons bool) {
 	f.Lock()
 	defer f.Unlock()
 
+	for _, item := range f.items {
+		f.releaseItem(item)
+	}
 	f.items = nil
+	f.validators = nil
 	if includeButtons {
-		f.buttons = nil
+		f.clearButtons()
 	}
 	f.focusedElement = 0
 }
@@ -472,6 +671,15 @@ func (f *Form) ClearButtons() {
 	f.Lock()
 	defer f.Unlock()
 
+	f.clearButtons()
+}
+
+// clearButtons removes all buttons from the form. The caller must hold the
+// lock.
+func (f *Form) clearButtons() {
+	for _, button := range f.buttons {
+		button.setContainerCapture(nil)
+	}
 	f.buttons = nil
 }
 
@@ -493,7 +701,33 @@ func (f *Form) AddFormItem(item FormItem) {
 		panic("Invalid FormItem")
 	}
 
+	f.addItem(item)
+}
+
+// addItem adds a form item and notifies the changed handler of the form when
+// the value of the item changes.
+func (f *Form) addItem(item FormItem) {
 	f.items = append(f.items, item)
+
+	if s, ok := item.(labelSuffixer); ok {
+		s.setFormLabelSuffix(f.labelSuffix)
+	}
+
+	if c, ok := item.(containerCapturer); ok {
+		c.setContainerCapture(f.captureSubmitKey)
+	}
+
+	if n, ok := item.(formChangeNotifier); ok {
+		n.setFormChangedFunc(func() {
+			f.RLock()
+			changed := f.changed
+			f.RUnlock()
+
+			if changed != nil {
+				changed(item)
+			}
+		})
+	}
 }
 
 // GetFormItemCount returns the number of items in the form (not including the
@@ -536,9 +770,205 @@ func (f *Form) RemoveFormItem(index int) {
 	f.Lock()
 	defer f.Unlock()
 
+	f.releaseItem(f.items[index])
 	f.items = append(f.items[:index], f.items[index+1:]...)
 }
 
+// releaseItem removes the handlers installed by addItem from a form item which
+// is removed from the form.
+func (f *Form) releaseItem(item FormItem) {
+	if s, ok := item.(labelSuffixer); ok {
+		s.setFormLabelSuffix("")
+	}
+	if n, ok := item.(formChangeNotifier); ok {
+		n.setFormChangedFunc(nil)
+	}
+	if c, ok := item.(containerCapturer); ok {
+		c.setContainerCapture(nil)
+	}
+}
+
+// SetItemValidator sets a function which validates the value of the form item
+// at the given index when Validate is called. The function receives the value
+// of the item as returned by FormValuer.GetFieldValue (e.g. the text of an
+// InputField or "true" or "false" for a CheckBox) and returns an error when
+// the value is invalid. Pass nil to remove the validator.
+func (f *Form) SetItemValidator(index int, validator func(value string) error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if index < 0 || index >= len(f.items) {
+		return
+	}
+	item := f.items[index]
+	if validator == nil {
+		delete(f.validators, item)
+		return
+	}
+	if f.validators == nil {
+		f.validators = make(map[FormItem]func(value string) error)
+	}
+	f.validators[item] = validator
+}
+
+// Validate validates the values of all form items which have a validator (see
+// SetItemValidator) and returns a *FormValidationError for each item which
+// failed validation. The field note of each validated item which supports
+// field notes (e.g. InputField) is set to the validation error, or removed
+// when the item passed validation.
+func (f *Form) Validate() []error {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	validators := make(map[FormItem]func(value string) error, len(f.validators))
+	for item, validator := range f.validators {
+		validators[item] = validator
+	}
+	f.RUnlock()
+
+	var errs []error
+	for index, item := range items {
+		validator := validators[item]
+		if validator == nil {
+			continue
+		}
+
+		err := validator(formItemValue(item))
+		if err != nil {
+			errs = append(errs, &FormValidationError{Index: index, Label: item.GetLabel(), Err: err})
+			if n, ok := item.(interface{ SetFieldNote(string) }); ok {
+				n.SetFieldNote(err.Error())
+			}
+		} else if n, ok := item.(interface{ ResetFieldNote() }); ok {
+			n.ResetFieldNote()
+		}
+	}
+	return errs
+}
+
+// GetValues returns the values of all form items as returned by
+// FormValuer.GetFieldValue, keyed by their labels. Form items which do not
+// implement FormValuer have an empty value. When multiple form items share a
+// label, the first item is keyed by its label and each following item is
+// keyed by its label followed by "#" and the index of the item (e.g.
+// "Name#3").
+func (f *Form) GetValues() map[string]string {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	f.RUnlock()
+
+	values := make(map[string]string, len(items))
+	for index, key := range formItemKeys(items) {
+		values[key] = formItemValue(items[index])
+	}
+	return values
+}
+
+// SetValues sets the values of the form items with the given keys. Keys are
+// determined as described in GetValues. Values of form items which are not
+// present in the map are left unchanged, as are values which are rejected by
+// FormValuer.SetFieldValue (e.g. the text of an option which does not exist)
+// and form items which do not implement FormValuer.
+func (f *Form) SetValues(values map[string]string) {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	f.RUnlock()
+
+	for index, key := range formItemKeys(items) {
+		if value, ok := values[key]; ok {
+			if v, ok := items[index].(FormValuer); ok {
+				v.SetFieldValue(value)
+			}
+		}
+	}
+}
+
+// MarshalJSON returns the state of all form items which implement
+// StateMarshaler as a JSON object, keyed by the labels of the form items as
+// described in GetValues. Other form items are skipped.
+func (f *Form) MarshalJSON() ([]byte, error) {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	f.RUnlock()
+
+	state := make(map[string]json.RawMessage, len(items))
+	for index, key := range formItemKeys(items) {
+		m, ok := items[index].(StateMarshaler)
+		if !ok {
+			continue
+		}
+		data, err := m.MarshalState()
+		if err != nil {
+			return nil, err
+		}
+		state[key] = data
+	}
+	return json.Marshal(state)
+}
+
+// UnmarshalJSON restores the state of all form items which implement
+// StateMarshaler from JSON returned by MarshalJSON. Form items which are not
+// present in the JSON object are left unchanged, and keys which do not match
+// any form item are ignored.
+func (f *Form) UnmarshalJSON(data []byte) error {
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	f.RUnlock()
+
+	for index, key := range formItemKeys(items) {
+		m, ok := items[index].(StateMarshaler)
+		if !ok {
+			continue
+		}
+		itemState, ok := state[key]
+		if !ok {
+			continue
+		}
+		if err := m.UnmarshalState(itemState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveState saves the current values of all form items. Call Reset to restore
+// them.
+func (f *Form) SaveState() {
+	f.Lock()
+	defer f.Unlock()
+
+	f.savedValues = make(map[FormItem]string, len(f.items))
+	for _, item := range f.items {
+		f.savedValues[item] = formItemValue(item)
+	}
+}
+
+// Reset restores the values of all form items saved by SaveState and calls
+// the changed handlers of each item whose value changes. Items which were added after SaveState was
+// called, or all items if SaveState was never called, are cleared instead
+// (e.g. InputFields are emptied and CheckBoxes are unchecked).
+func (f *Form) Reset() {
+	f.RLock()
+	items := make([]FormItem, len(f.items))
+	copy(items, f.items)
+	savedValues := f.savedValues
+	f.RUnlock()
+
+	for _, item := range items {
+		value, saved := savedValues[item]
+		resetFormItem(item, value, saved)
+	}
+}
+
 // GetFormItemByLabel returns the first form element with the given label. If
 // no such element is found, nil is returned. Buttons are not searched and will
 // therefore not be returned.
@@ -569,6 +999,22 @@ func (f *Form) GetFormItemIndex(label string) int {
 	return -1
 }
 
+// SetTabOrder sets the order in which form items and buttons receive focus
+// when navigating the form (e.g. via Tab and Backtab). Elements are referenced
+// by their index, counting non-button items first and buttons last (see
+// SetFocus). Elements which are not included follow the listed elements in the
+// order they were added. Invalid and duplicate indices are ignored. Pass nil to
+// restore the natural order.
+//
+// This is useful when the visual layout differs from the order in which the
+// elements were added.
+func (f *Form) SetTabOrder(indices []int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.tabOrder = append([]int(nil), indices...)
+}
+
 // GetFocusedItemIndex returns the indices of the form element or button which
 // currently has focus. If they don't, -1 is returned resepectively.
 func (f *Form) GetFocusedItemIndex() (formItem, button int) {
@@ -606,6 +1052,116 @@ func (f *Form) SetCancelFunc(callback func()) {
 	f.cancel = callback
 }
 
+// SetChangedFunc sets a handler which is called when the value of any form
+// item changes, e.g. to detect unsaved changes or to update a live preview.
+// The handler receives the form item which changed. It is called after the
+// changed handler of the item itself, which remains in place. This is
+// supported by InputField, CheckBox, DropDown and Slider.
+func (f *Form) SetChangedFunc(handler func(item FormItem)) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.changed = handler
+}
+
+// SetSubmitFunc sets a handler which is called when the user submits the form,
+// either by pressing the submit key (see SetSubmitKey) while any form item or
+// button has focus, or by pressing Enter in the last form item. The form is
+// validated first (see Validate). When validation fails, the handler is not
+// called and the first invalid form item receives focus.
+//
+// Form items which consume Enter themselves (e.g. a multi-line text editor
+// added via AddFormItem, or a DropDown, which opens its list) never report it
+// to the form, so Enter does not submit the form from such items. Use the
+// submit key instead.
+//
+// The submit key is processed after the input capture function of the focused
+// form item or button (see Box.SetInputCapture), which may therefore consume
+// it.
+func (f *Form) SetSubmitFunc(handler func()) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.submit = handler
+}
+
+// SetSubmitKey sets the key which submits the form from any form item or
+// button. The default is Ctrl-S. Pass tcell.KeyNUL to only submit via Enter in
+// the last form item.
+func (f *Form) SetSubmitKey(key tcell.Key) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.submitKey = key
+}
+
+// setEnterFunc sets a function which is called when a form item reports
+// Enter to the form. When it returns true, the focus is not moved to the next
+// form item and the form is not submitted.
+func (f *Form) setEnterFunc(handler func() bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.enter = handler
+}
+
+// submitForm validates the form and calls the submit handler when all form
+// items are valid. Otherwise, the first invalid form item receives focus.
+func (f *Form) submitForm() {
+	errs := f.Validate()
+
+	f.Lock()
+	if len(errs) > 0 {
+		var validationErr *FormValidationError
+		if errors.As(errs[0], &validationErr) {
+			f.focusedElement = validationErr.Index
+		}
+		delegate := f.delegate
+		f.Unlock()
+
+		if delegate != nil {
+			f.Focus(delegate)
+		}
+		return
+	}
+	submit := f.submit
+	f.Unlock()
+
+	if submit != nil {
+		submit()
+	}
+}
+
+// captureSubmitKey submits the form when the submit key is pressed while a
+// form item or button has focus. It is installed on each form item and button
+// when it is added to the form.
+func (f *Form) captureSubmitKey(event *tcell.EventKey) *tcell.EventKey {
+	f.RLock()
+	submit, submitKey := f.submit, f.submitKey
+	f.RUnlock()
+
+	if submit == nil || submitKey == tcell.KeyNUL || event.Key() != submitKey {
+		return event
+	}
+	f.submitForm()
+	return nil
+}
+
+// isLastItem returns whether no visible, enabled form item follows the form
+// item with the given index in the focus order.
+func (f *Form) isLastItem(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	order := f.focusOrder()
+	for position := len(order) - 1; order[position] != index; position-- {
+		if order[position] < len(f.items) && f.elementFocusable(order[position]) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetAttributes returns the current attribute settings of a form.
 func (f *Form) GetAttributes() *FormItemAttributes {
 	f.Lock()
@@ -651,8 +1207,9 @@ func (f *Form) Draw(screen tcell.Screen) {
 	defer f.Unlock()
 
 	// Determine the actual item that has focus.
-	if index := f.focusIndex(); index >= 0 {
-		f.focusedElement = index
+	focusIndex := f.focusIndex()
+	if focusIndex >= 0 {
+		f.focusedElement = focusIndex
 	}
 
 	// Determine the dimensions.
@@ -662,10 +1219,14 @@ func (f *Form) Draw(screen tcell.Screen) {
 	rightLimit := x + width
 	startX := x
 
-	// Find the longest label.
+	// Find the longest label. Labels drawn above their field do not affect the
+	// alignment of the other fields.
 	var maxLabelWidth int
 	for _, item := range f.items {
-		labelWidth := TaggedStringWidth(item.GetLabel())
+		if labelAbove(item) {
+			continue
+		}
+		labelWidth := formItemLabelWidth(item)
 		if labelWidth > maxLabelWidth {
 			maxLabelWidth = labelWidth
 		}
@@ -675,13 +1236,15 @@ func (f *Form) Draw(screen tcell.Screen) {
 	// Calculate positions of form items.
 	positions := make([]struct{ x, y, width, height int }, len(f.items)+len(f.buttons))
 	var focusedPosition struct{ x, y, width, height int }
+	rowHeight := 1
 	for index, item := range f.items {
 		if !item.GetVisible() {
 			continue
 		}
 
 		// Calculate the space needed.
-		labelWidth := TaggedStringWidth(item.GetLabel())
+		above := labelAbove(item)
+		labelWidth := formItemLabelWidth(item)
 		var itemWidth int
 		if f.horizontal {
 			fieldWidth := item.GetFieldWidth()
@@ -690,16 +1253,25 @@ func (f *Form) Draw(screen tcell.Screen) {
 			}
 			labelWidth++
 			itemWidth = labelWidth + fieldWidth
+			if above && fieldWidth > labelWidth {
+				itemWidth = fieldWidth
+			} else if above {
+				itemWidth = labelWidth
+			}
 		} else {
 			// We want all fields to align vertically.
 			labelWidth = maxLabelWidth
 			itemWidth = width
 		}
+		if above {
+			labelWidth = 0
+		}
 
 		// Advance to next line if there is no space.
 		if f.horizontal && x+labelWidth+1 >= rightLimit {
 			x = startX
-			y += 2
+			y += rowHeight + 1
+			rowHeight = 1
 		}
 
 		// Adjust the item's attributes.
@@ -709,13 +1281,24 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 		attributes := f.getAttributes()
 		attributes.LabelWidth = labelWidth
+		if index == focusIndex && f.focusHighlightColor != ColorUnset {
+			attributes.BackgroundColor = f.focusHighlightColor
+		}
 		setFormItemAttributes(item, attributes)
 
+		// The height of some items (e.g. wrapped text) depends on their width,
+		// so the width is applied before the height is determined.
+		item.SetRect(x, y, itemWidth, 1)
+		itemHeight := item.GetFieldHeight()
+		if itemHeight > rowHeight {
+			rowHeight = itemHeight
+		}
+
 		// Save position.
 		positions[index].x = x
 		positions[index].y = y
 		positions[index].width = itemWidth
-		positions[index].height = 1
+		positions[index].height = itemHeight
 		if item.GetFocusable().HasFocus() {
 			focusedPosition = positions[index]
 		}
@@ -724,7 +1307,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 		if f.horizontal {
 			x += itemWidth + f.itemPadding
 		} else {
-			y += item.GetFieldHeight() + f.itemPadding
+			y += itemHeight + f.itemPadding
 		}
 	}
 
@@ -763,7 +1346,8 @@ func (f *Form) Draw(screen tcell.Screen) {
 		if f.horizontal {
 			if space < buttonWidth-4 {
 				x = startX
-				y += 2
+				y += rowHeight + 1
+				rowHeight = 1
 				space = width
 			}
 		} else {
@@ -801,6 +1385,19 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Highlight the row of the focused item.
+	if focusIndex >= 0 && f.focusHighlightColor != ColorUnset {
+		highlightStyle := tcell.StyleDefault.Background(f.focusHighlightColor)
+		for y := focusedPosition.y - offset; y < focusedPosition.y-offset+focusedPosition.height; y++ {
+			if y < topLimit || y >= bottomLimit {
+				continue
+			}
+			for x := startX; x < rightLimit; x++ {
+				screen.SetContent(x, y, ' ', nil, highlightStyle)
+			}
+		}
+	}
+
 	// Draw items.
 	for index, item := range f.items {
 		if !item.GetVisible() {
@@ -847,43 +1444,80 @@ func (f *Form) Draw(screen tcell.Screen) {
 	}
 }
 
-func (f *Form) updateFocusedElement(decreasing bool) {
-	li := len(f.items)
+// focusOrder returns the indices of all form items and buttons in the order in
+// which they receive focus (see SetTabOrder).
+func (f *Form) focusOrder() []int {
 	l := len(f.items) + len(f.buttons)
+	order := make([]int, 0, l)
+	ordered := make([]bool, l)
+	for _, index := range f.tabOrder {
+		if index >= 0 && index < l && !ordered[index] {
+			order = append(order, index)
+			ordered[index] = true
+		}
+	}
+	for index := 0; index < l; index++ {
+		if !ordered[index] {
+			order = append(order, index)
+		}
+	}
+	return order
+}
+
+// focusPosition returns the position of the focused element in the focus
+// order, or -1 if it is not part of the form.
+func (f *Form) focusPosition(order []int) int {
+	for position, index := range order {
+		if index == f.focusedElement {
+			return position
+		}
+	}
+	return -1
+}
+
+// elementFocusable returns whether the form item or button with the given
+// index may receive focus.
+func (f *Form) elementFocusable(index int) bool {
+	if index < len(f.items) {
+		item := f.items[index]
+		return item.GetVisible() && !formItemDisabled(item)
+	}
+	button := f.buttons[index-len(f.items)]
+	return button.GetVisible() && !button.IsDisabled()
+}
+
+// updateFocusedElement focuses the first element which may receive focus,
+// starting at the given position in the focus order and moving in the given
+// direction.
+func (f *Form) updateFocusedElement(position int, decreasing bool) {
+	order := f.focusOrder()
+	l := len(order)
 	for i := 0; i < l; i++ {
-		if f.focusedElement < 0 {
+		if position < 0 {
 			if f.wrapAround {
-				f.focusedElement = l - 1
+				position = l - 1
 			} else {
-				f.focusedElement = 0
+				position = 0
 			}
-		} else if f.focusedElement >= l {
+		} else if position >= l {
 			if f.wrapAround {
-				f.focusedElement = 0
+				position = 0
 			} else {
-				f.focusedElement = l - 1
+				position = l - 1
 			}
 		}
 
-		if f.focusedElement < li {
-			item := f.items[f.focusedElement]
-			if item.GetVisible() {
-				break
-			}
-		} else {
-			button := f.buttons[f.focusedElement-li]
-			if button.GetVisible() {
-				break
-			}
+		f.focusedElement = order[position]
+		if f.elementFocusable(f.focusedElement) {
+			break
 		}
 
 		if decreasing {
-			f.focusedElement--
+			position--
 		} else {
-			f.focusedElement++
+			position++
 		}
 	}
-
 }
 
 func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.Key) {
@@ -892,14 +1526,27 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 
 		switch key {
 		case tcell.KeyTab, tcell.KeyEnter:
-			f.focusedElement++
-			f.updateFocusedElement(false)
+			if key == tcell.KeyEnter && f.enter != nil {
+				enter := f.enter
+				f.Unlock()
+				handled := enter()
+				f.Lock()
+				if handled {
+					break
+				}
+			}
+			if key == tcell.KeyEnter && f.submit != nil && f.isLastItem(f.focusedElement) {
+				f.Unlock()
+				f.submitForm()
+				f.Lock()
+				break
+			}
+			f.updateFocusedElement(f.focusPosition(f.focusOrder())+1, false)
 			f.Unlock()
 			f.Focus(delegate)
 			f.Lock()
 		case tcell.KeyBacktab:
-			f.focusedElement--
-			f.updateFocusedElement(true)
+			f.updateFocusedElement(f.focusPosition(f.focusOrder())-1, true)
 			f.Unlock()
 			f.Focus(delegate)
 			f.Lock()
@@ -909,8 +1556,7 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 				f.cancel()
 				f.Lock()
 			} else {
-				f.focusedElement = 0
-				f.updateFocusedElement(true)
+				f.updateFocusedElement(0, true)
 				f.Unlock()
 				f.Focus(delegate)
 				f.Lock()
@@ -924,6 +1570,7 @@ func (f *Form) formItemInputHandler(delegate func(p Primitive)) func(key tcell.K
 // Focus is called by the application when the primitive receives focus.
 func (f *Form) Focus(delegate func(p Primitive)) {
 	f.Lock()
+	f.delegate = delegate
 	if len(f.items)+len(f.buttons) == 0 {
 		f.hasFocus = true
 		f.Unlock()
@@ -935,6 +1582,7 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 	if f.focusedElement < 0 || f.focusedElement >= len(f.items)+len(f.buttons) {
 		f.focusedElement = 0
 	}
+	f.updateFocusedElement(f.focusPosition(f.focusOrder()), false)
 
 	if f.focusedElement < len(f.items) {
 		// We're selecting an item.
@@ -993,6 +1641,17 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return false, nil
 		}
 
+		// Remember how to shift the focus when elements receive focus via the
+		// mouse, so that submitting can focus an invalid item.
+		delegate := setFocus
+		setFocus = func(p Primitive) {
+			f.Lock()
+			f.delegate = delegate
+			f.Unlock()
+
+			delegate(p)
+		}
+
 		// Determine items to pass mouse events to.
 		for _, item := range f.items {
 			consumed, capture = item.MouseHandler()(action, event, setFocus)
@@ -1022,6 +1681,81 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 	})
 }
 
+// formItemDisabled returns whether the given form item is disabled and should
+// therefore be skipped when navigating the form.
+func formItemDisabled(item FormItem) bool {
+	d, ok := item.(interface{ IsDisabled() bool })
+	return ok && d.IsDisabled()
+}
+
+// formItemValue returns the value of the given form item (see FormValuer). An
+// empty string is returned for form items which do not implement FormValuer.
+func formItemValue(item FormItem) string {
+	if v, ok := item.(FormValuer); ok {
+		return v.GetFieldValue()
+	}
+	return ""
+}
+
+// resetFormItem restores the given value of a form item as returned by
+// GetFieldValue, or clears the form item when saved is false, and calls the
+// changed handler of the item.
+func resetFormItem(item FormItem, value string, saved bool) {
+	switch item := item.(type) {
+	case *InputField:
+		item.SetText(value) // Calls the changed handler.
+	case *CheckBox:
+		state, index, err := item.parseFieldValue(value)
+		if !saved || err != nil {
+			state, index = CheckBoxUnchecked, -1
+		}
+		if index >= 0 {
+			item.SetStateIndexWithCallback(index)
+		} else {
+			item.SetStateWithCallback(state)
+		}
+	case *DropDown:
+		if saved && value != "" {
+			item.SetFieldValue(value)
+		} else {
+			item.SetCurrentOption(-1) // Calls the selected handler.
+		}
+	case *RadioGroup:
+		if saved && value != "" {
+			item.SetFieldValue(value)
+		} else {
+			item.SetSelected(-1)
+		}
+
+		item.RLock()
+		index, selectedFunc := item.selected, item.selectedFunc
+		item.RUnlock()
+		if index >= 0 && selectedFunc != nil {
+			selectedFunc(index, value)
+		}
+	case *Slider:
+		progress, _ := strconv.Atoi(value)
+		item.SetProgressWithCallback(progress)
+	}
+}
+
+// formItemKeys returns the keys of the given form items as described in
+// Form.GetValues.
+func formItemKeys(items []FormItem) []string {
+	keys := make([]string, len(items))
+	seen := make(map[string]bool, len(items))
+	for index, item := range items {
+		label := item.GetLabel()
+		if seen[label] {
+			keys[index] = label + "#" + strconv.Itoa(index)
+		} else {
+			keys[index] = label
+			seen[label] = true
+		}
+	}
+	return keys
+}
+
 func setFormItemAttributes(item FormItem, attrs *FormItemAttributes) {
 	item.SetLabelWidth(attrs.LabelWidth)
 	item.SetBackgroundColor(attrs.BackgroundColor)
@@ -0,0 +1,652 @@
+package cview
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	testFormLabelA = "Name"
+	testFormLabelB = "Subscribe"
+)
+
+func TestForm(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	f := NewForm()
+	f.AddInputField(testFormLabelA, "Hello", 0, nil, nil)
+	f.AddCheckBox(testFormLabelB, "", true, nil)
+	f.AddInputField(testFormLabelA, "World", 0, nil, nil)
+	if f.GetFormItemCount() != 3 {
+		t.Errorf("failed to add Form items: incorrect item count: expected 3, got %d", f.GetFormItemCount())
+	}
+
+	// Get values
+
+	values := f.GetValues()
+	expected := map[string]string{
+		testFormLabelA:        "Hello",
+		testFormLabelB:        "true",
+		testFormLabelA + "#2": "World",
+	}
+	for key, value := range expected {
+		if values[key] != value {
+			t.Errorf("failed to get Form values: incorrect value for %s: expected %s, got %s", key, value, values[key])
+		}
+	}
+
+	// Set values
+
+	f.SetValues(map[string]string{testFormLabelA + "#2": "Moon", testFormLabelB: "false"})
+	if text := f.GetFormItem(2).(*InputField).GetText(); text != "Moon" {
+		t.Errorf("failed to set Form values: incorrect text: expected Moon, got %s", text)
+	} else if f.GetFormItem(1).(*CheckBox).IsChecked() {
+		t.Errorf("failed to set Form values: incorrect state: expected unchecked, got checked")
+	} else if text := f.GetFormItem(0).(*InputField).GetText(); text != "Hello" {
+		t.Errorf("failed to set Form values: unexpected change: expected Hello, got %s", text)
+	}
+
+	// Reset
+
+	f.SaveState()
+	f.GetFormItem(0).(*InputField).SetText("Goodbye")
+	f.AddInputField(testFormLabelB, "Added", 0, nil, nil)
+	f.Reset()
+	if text := f.GetFormItem(0).(*InputField).GetText(); text != "Hello" {
+		t.Errorf("failed to reset Form: incorrect text: expected Hello, got %s", text)
+	} else if text := f.GetFormItem(3).(*InputField).GetText(); text != "" {
+		t.Errorf("failed to reset Form: incorrect text of added item: expected empty text, got %s", text)
+	}
+
+	// Draw
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.Draw(app.screen)
+
+	// Horizontal layout
+
+	f.SetHorizontal(true)
+	f.SetRect(0, 0, 80, 10)
+	f.Draw(app.screen)
+
+	x0, y0, _, _ := f.GetFormItem(0).GetRect()
+	x1, y1, _, _ := f.GetFormItem(1).GetRect()
+	if y1 != y0 || x1 <= x0 {
+		t.Errorf("failed to lay out Form horizontally: expected second item to the right of %d,%d, got %d,%d", x0, y0, x1, y1)
+	}
+
+	// Label above
+
+	f.SetHorizontal(false)
+	f.GetFormItem(0).(*InputField).SetLabelPosition(LabelAbove)
+	f.Draw(app.screen)
+
+	x0, y0, _, h0 := f.GetFormItem(0).GetRect()
+	_, y1, _, _ = f.GetFormItem(1).GetRect()
+	if h0 != 2 || y1 != y0+2+f.itemPadding {
+		t.Errorf("failed to lay out Form with label above: expected height 2 and next item at %d, got height %d and next item at %d", y0+2+f.itemPadding, h0, y1)
+	}
+	if r, _, _, _ := app.screen.GetContent(x0, y0); r != 'N' {
+		t.Errorf("failed to draw label above field: expected N, got %c", r)
+	}
+	if r, _, _, _ := app.screen.GetContent(x0, y0+1); r != 'H' {
+		t.Errorf("failed to draw field below label: expected H, got %c", r)
+	}
+
+	// Focus highlight
+
+	f.SetFocusHighlightColor(tcell.ColorBlue)
+	f.GetFormItem(0).Blur()
+	f.GetFormItem(1).Focus(func(p Primitive) {})
+	f.Draw(app.screen)
+
+	fx, _, fwidth, _ := f.GetInnerRect()
+	_, y1, _, _ = f.GetFormItem(1).GetRect()
+	for _, x := range []int{fx, fx + fwidth - 1} {
+		if _, _, style, _ := app.screen.GetContent(x, y1); !hasBackground(style, tcell.ColorBlue) {
+			t.Errorf("failed to highlight focused Form item: expected blue background at %d,%d", x, y1)
+		}
+	}
+	if _, _, style, _ := app.screen.GetContent(fx+fwidth-1, y0); hasBackground(style, tcell.ColorBlue) {
+		t.Errorf("failed to highlight focused Form item: unexpected blue background at %d,%d", fx+fwidth-1, y0)
+	}
+	f.GetFormItem(1).Blur()
+}
+
+// hasBackground returns whether the given style has the given background
+// color.
+func hasBackground(style tcell.Style, color tcell.Color) bool {
+	_, bg, _ := style.Decompose()
+	return bg == color
+}
+
+func TestFormReset(t *testing.T) {
+	t.Parallel()
+
+	triState := NewCheckBox()
+	triState.SetTriState(true)
+	triState.SetState(CheckBoxIndeterminate)
+	enum := NewCheckBox()
+	enum.SetStates([]string{"Low", "Medium", "High"})
+	enum.SetStateIndex(2)
+	slider := NewSlider()
+	slider.SetProgress(70)
+
+	f := NewForm()
+	f.AddFormItem(triState)
+	f.AddFormItem(enum)
+	f.AddFormItem(slider)
+	f.SaveState()
+
+	changed := make(map[FormItem]int)
+	f.SetChangedFunc(func(item FormItem) {
+		changed[item]++
+	})
+	var stateChanged, statesChanged, sliderChanged int
+	triState.SetStateChangedFunc(func(state CheckBoxState) {
+		stateChanged++
+	})
+	enum.SetStatesChangedFunc(func(index int, state string) {
+		statesChanged++
+	})
+	slider.SetChangedFunc(func(value int) {
+		sliderChanged++
+	})
+
+	triState.SetChecked(false)
+	enum.SetStateIndex(1)
+	slider.SetProgress(10)
+	f.Reset()
+	if triState.GetState() != CheckBoxIndeterminate {
+		t.Errorf("failed to reset tri-state CheckBox: expected state %d, got %d", CheckBoxIndeterminate, triState.GetState())
+	} else if enum.GetStateIndex() != 2 {
+		t.Errorf("failed to reset CheckBox states: expected index 2, got %d", enum.GetStateIndex())
+	} else if slider.GetProgress() != 70 {
+		t.Errorf("failed to reset Slider: expected 70, got %d", slider.GetProgress())
+	}
+	if stateChanged != 1 || statesChanged != 1 || sliderChanged != 1 {
+		t.Errorf("failed to call changed handlers on reset: expected 1, 1 and 1 calls, got %d, %d and %d", stateChanged, statesChanged, sliderChanged)
+	}
+	for _, item := range []FormItem{triState, enum, slider} {
+		if changed[item] != 1 {
+			t.Errorf("failed to call Form changed handler on reset: expected 1 call for %s, got %d", item.GetLabel(), changed[item])
+		}
+	}
+
+	// Unchanged items do not call the changed handlers.
+
+	f.Reset()
+	if stateChanged != 1 || changed[triState] != 1 {
+		t.Errorf("failed to skip unchanged items on reset: expected 1 call, got %d and %d", stateChanged, changed[triState])
+	}
+
+	// Items which were not saved are cleared.
+
+	added := NewCheckBox()
+	added.SetStates([]string{"Off", "On"})
+	added.SetStateIndex(1)
+	f.AddFormItem(added)
+	f.Reset()
+	if added.GetStateIndex() != 0 || added.IsChecked() {
+		t.Errorf("failed to clear added CheckBox: expected index 0 and unchecked, got %d and %t", added.GetStateIndex(), added.IsChecked())
+	}
+}
+
+func TestFormSubmit(t *testing.T) {
+	t.Parallel()
+
+	var submitted int
+	f := NewForm()
+	f.AddInputField(testFormLabelA, "", 0, nil, nil)
+	f.AddInputField(testFormLabelB, "", 0, nil, nil)
+	f.AddButton("Save", nil)
+	f.SetSubmitFunc(func() {
+		submitted++
+	})
+	f.SetItemValidator(0, func(value string) error {
+		if value == "" {
+			return errors.New("required")
+		}
+		return nil
+	})
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	sendKey := func(key tcell.Key) {
+		app.GetFocus().InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {
+			app.SetFocus(p)
+		})
+	}
+
+	// Invalid form
+
+	f.SetFocus(1)
+	app.SetFocus(f)
+	sendKey(tcell.KeyCtrlS)
+	if submitted != 0 {
+		t.Errorf("failed to validate Form before submitting: expected no submission, got %d", submitted)
+	} else if app.GetFocus() != f.GetFormItem(0) {
+		t.Error("failed to focus invalid Form item after submitting")
+	}
+
+	// Submit key
+
+	f.GetFormItem(0).(*InputField).SetText("Hello")
+	sendKey(tcell.KeyCtrlS)
+	if submitted != 1 {
+		t.Errorf("failed to submit Form via submit key: expected 1 submission, got %d", submitted)
+	}
+
+	// Enter
+
+	sendKey(tcell.KeyEnter)
+	if submitted != 1 || app.GetFocus() != f.GetFormItem(1) {
+		t.Errorf("failed to move to next Form item via Enter: expected 1 submission, got %d", submitted)
+	}
+	sendKey(tcell.KeyEnter)
+	if submitted != 2 {
+		t.Errorf("failed to submit Form via Enter in last item: expected 2 submissions, got %d", submitted)
+	}
+
+	// Input capture
+
+	item := f.GetFormItem(1).(*InputField)
+	var captured int
+	item.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		captured++
+		return event
+	})
+	sendKey(tcell.KeyCtrlS)
+	if submitted != 3 || captured != 1 {
+		t.Errorf("failed to submit Form after setting input capture: expected 3 submissions and 1 capture, got %d and %d", submitted, captured)
+	}
+
+	// Removed item
+
+	f.RemoveFormItem(1)
+	item.InputHandler()(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModNone), func(p Primitive) {})
+	if submitted != 3 {
+		t.Errorf("failed to stop submitting from removed Form item: expected 3 submissions, got %d", submitted)
+	}
+}
+
+func TestFormValidate(t *testing.T) {
+	t.Parallel()
+
+	errRequired := errors.New("required")
+
+	f := NewForm()
+	f.AddInputField(testFormLabelA, "", 0, nil, nil)
+	f.AddCheckBox(testFormLabelB, "", false, nil)
+	f.AddInputField("Comment", "", 0, nil, nil)
+	f.SetItemValidator(0, func(value string) error {
+		if value == "" {
+			return errRequired
+		}
+		return nil
+	})
+	f.SetItemValidator(1, func(value string) error {
+		if value != "true" {
+			return errRequired
+		}
+		return nil
+	})
+
+	input := f.GetFormItem(0).(*InputField)
+	fieldNote := func() string {
+		input.RLock()
+		defer input.RUnlock()
+		return string(input.fieldNote)
+	}
+
+	errs := f.Validate()
+	if len(errs) != 2 {
+		t.Fatalf("failed to validate Form: expected 2 errors, got %v", errs)
+	}
+	var validationErr *FormValidationError
+	if !errors.As(errs[1], &validationErr) || validationErr.Index != 1 || validationErr.Label != testFormLabelB {
+		t.Errorf("failed to validate Form: expected error for item 1 %s, got %v", testFormLabelB, errs[1])
+	} else if !errors.Is(errs[0], errRequired) {
+		t.Errorf("failed to validate Form: expected wrapped validator error, got %v", errs[0])
+	} else if fieldNote() != errRequired.Error() {
+		t.Errorf("failed to set field note: expected %q, got %q", errRequired.Error(), fieldNote())
+	}
+
+	input.SetText("Hello")
+	f.GetFormItem(1).(*CheckBox).SetChecked(true)
+	if errs := f.Validate(); len(errs) != 0 {
+		t.Errorf("failed to validate Form: expected no errors, got %v", errs)
+	} else if fieldNote() != "" {
+		t.Errorf("failed to reset field note: got %q", fieldNote())
+	}
+
+	input.SetText("")
+	f.SetItemValidator(0, nil)
+	if errs := f.Validate(); len(errs) != 0 {
+		t.Errorf("failed to remove validator: expected no errors, got %v", errs)
+	}
+}
+
+func TestFormTabOrder(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddInputField("A", "", 0, nil, nil)
+	f.AddInputField("B", "", 0, nil, nil)
+	f.AddInputField("C", "", 0, nil, nil)
+	f.AddButton("Save", nil)
+	f.SetTabOrder([]int{2, 0, 7})
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	sendKey := func(key tcell.Key) {
+		app.GetFocus().InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {
+			app.SetFocus(p)
+		})
+	}
+	focused := func() int {
+		formItem, button := f.GetFocusedItemIndex()
+		if button >= 0 {
+			return f.GetFormItemCount() + button
+		}
+		return formItem
+	}
+
+	// Tab
+
+	f.SetFocus(2)
+	app.SetFocus(f)
+	for _, expected := range []int{0, 1, 3, 3} {
+		sendKey(tcell.KeyTab)
+		if focused() != expected {
+			t.Errorf("failed to follow Form tab order: expected element %d, got %d", expected, focused())
+		}
+	}
+
+	// Backtab
+
+	for _, expected := range []int{1, 0, 2, 2} {
+		sendKey(tcell.KeyBacktab)
+		if focused() != expected {
+			t.Errorf("failed to follow Form tab order backwards: expected element %d, got %d", expected, focused())
+		}
+	}
+}
+
+func TestFormFocusByLabel(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddInputField("A", "", 0, nil, nil)
+	f.AddInputField("B", "", 0, nil, nil)
+	f.AddButton("Save", nil)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	if !f.SetFocusByLabel("B") {
+		t.Error("failed to focus Form item by label: expected true, got false")
+	}
+	app.SetFocus(f)
+	if formItem, button := f.GetFocusedItemIndex(); formItem != 1 || button != -1 {
+		t.Errorf("failed to focus Form item by label: expected item 1, got item %d and button %d", formItem, button)
+	}
+	if app.GetFocus() != f.GetFormItem(1) {
+		t.Error("failed to focus Form item by label: incorrect focused primitive")
+	}
+
+	for _, label := range []string{"C", "Save"} {
+		if f.SetFocusByLabel(label) {
+			t.Errorf("failed to ignore missing Form item label %s: expected false, got true", label)
+		}
+		if formItem, _ := f.GetFocusedItemIndex(); formItem != 1 {
+			t.Errorf("failed to keep focus for missing Form item label %s: expected item 1, got %d", label, formItem)
+		}
+	}
+}
+
+func TestFormChanged(t *testing.T) {
+	t.Parallel()
+
+	var itemChanged bool
+	var changed []FormItem
+	f := NewForm()
+	f.AddInputField(testFormLabelA, "", 0, nil, func(text string) {
+		itemChanged = true
+	})
+	f.AddCheckBox(testFormLabelB, "", false, nil)
+	f.AddDropDownSimple("Color", 0, nil, "Red", "Green")
+	f.SetChangedFunc(func(item FormItem) {
+		changed = append(changed, item)
+	})
+
+	f.GetFormItem(0).(*InputField).SetText("Hello")
+	f.GetFormItem(1).(*CheckBox).SetCheckedWithCallback(true)
+	f.GetFormItem(2).(*DropDown).SetCurrentOption(1)
+	f.GetFormItem(2).(*DropDown).SetCurrentOption(1)
+	if !itemChanged {
+		t.Error("failed to call changed handler of Form item")
+	}
+	if len(changed) != 3 {
+		t.Fatalf("failed to call changed handler of Form: expected 3 calls, got %d", len(changed))
+	}
+	for index, item := range changed {
+		if item != f.GetFormItem(index) {
+			t.Errorf("failed to call changed handler of Form: unexpected item at call %d", index)
+		}
+	}
+
+	// Removed items
+
+	item := f.GetFormItem(0).(*InputField)
+	f.RemoveFormItem(0)
+	item.SetText("World")
+	if len(changed) != 3 {
+		t.Errorf("failed to remove Form item: expected no further calls, got %d", len(changed)-3)
+	}
+}
+
+func TestFormJSON(t *testing.T) {
+	t.Parallel()
+
+	newForm := func() *Form {
+		f := NewForm()
+		f.AddInputField(testFormLabelA, "", 0, nil, nil)
+		f.AddCheckBox(testFormLabelB, "", false, nil)
+		f.AddInputField(testFormLabelA, "", 0, nil, nil)
+		f.AddDropDownSimple("Color", 0, nil, "Red", "Green")
+		return f
+	}
+
+	f := newForm()
+	f.GetFormItem(0).(*InputField).SetText("Hello")
+	f.GetFormItem(1).(*CheckBox).SetChecked(true)
+	f.GetFormItem(2).(*InputField).SetText("World")
+	f.GetFormItem(2).(*InputField).SetCursorPosition(2)
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("failed to marshal Form: %s", err)
+	}
+
+	restored := newForm()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("failed to unmarshal Form: %s", err)
+	}
+	if text := restored.GetFormItem(0).(*InputField).GetText(); text != "Hello" {
+		t.Errorf("failed to restore Form: incorrect text: expected Hello, got %s", text)
+	} else if !restored.GetFormItem(1).(*CheckBox).IsChecked() {
+		t.Errorf("failed to restore Form: incorrect state: expected checked, got unchecked")
+	} else if text := restored.GetFormItem(2).(*InputField).GetText(); text != "World" {
+		t.Errorf("failed to restore Form: incorrect text: expected World, got %s", text)
+	} else if pos := restored.GetFormItem(2).(*InputField).GetCursorPosition(); pos != 2 {
+		t.Errorf("failed to restore Form: incorrect cursor position: expected 2, got %d", pos)
+	}
+}
+
+func TestFormItemFieldValue(t *testing.T) {
+	t.Parallel()
+
+	slider := NewSlider()
+	slider.SetMax(10)
+	dropDown := NewDropDown()
+	dropDown.SetOptionsSimple(nil, "Red", "Green")
+	radioGroup := NewRadioGroup()
+	radioGroup.AddOption("Small")
+	radioGroup.AddOption("Large")
+	triState := NewCheckBox()
+	triState.SetTriState(true)
+	enum := NewCheckBox()
+	enum.SetStates([]string{"Low", "Medium", "High"})
+
+	for _, test := range []struct {
+		item    FormValuer
+		value   string
+		invalid string
+	}{
+		{NewInputField(), "Hello", ""},
+		{NewCheckBox(), "true", "yes"},
+		{triState, "indeterminate", "yes"},
+		{enum, "High", "true"},
+		{dropDown, "Green", "Blue"},
+		{radioGroup, "Large", "Medium"},
+		{slider, "7", "seven"},
+	} {
+		if err := test.item.SetFieldValue(test.value); err != nil {
+			t.Errorf("failed to set field value %q: %s", test.value, err)
+		} else if value := test.item.GetFieldValue(); value != test.value {
+			t.Errorf("failed to get field value: expected %q, got %q", test.value, value)
+		}
+		if test.invalid == "" {
+			continue
+		}
+		if err := test.item.SetFieldValue(test.invalid); err != ErrInvalidFieldValue {
+			t.Errorf("failed to reject field value %q: expected ErrInvalidFieldValue, got %v", test.invalid, err)
+		} else if value := test.item.GetFieldValue(); value != test.value {
+			t.Errorf("failed to keep field value after rejecting %q: expected %q, got %q", test.invalid, test.value, value)
+		}
+	}
+
+	if err := dropDown.SetFieldValue(""); err != ErrInvalidFieldValue {
+		t.Errorf("failed to reject empty field value: expected ErrInvalidFieldValue, got %v", err)
+	} else if value := dropDown.GetFieldValue(); value != "Green" {
+		t.Errorf("failed to keep field value after rejecting empty value: expected Green, got %q", value)
+	}
+}
+
+func TestFormLabelSuffix(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddInputField("Name", "", 10, nil, nil)
+	f.AddInputField("E-mail", "", 10, nil, nil)
+	f.AddCheckBox("Agree", "", false, nil)
+	f.GetFormItem(2).(*CheckBox).SetLabelSuffix("?")
+	f.SetLabelSuffix(":")
+	f.SetRect(0, 0, 40, 10)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	f.Draw(app.screen)
+
+	label := func(index int, width int) string {
+		x, y, _, _ := f.GetFormItem(index).GetRect()
+		var b strings.Builder
+		for i := 0; i < width; i++ {
+			ch, _, _, _ := app.screen.GetContent(x+i, y)
+			b.WriteRune(ch)
+		}
+		return b.String()
+	}
+	for index, expected := range []string{"Name:", "E-mail:", "Agree?"} {
+		if l := label(index, len(expected)); l != expected {
+			t.Errorf("failed to draw label suffix: expected %q, got %q", expected, l)
+		}
+	}
+	if got := f.GetFormItem(0).GetLabel(); got != "Name" {
+		t.Errorf("failed to keep label: expected \"Name\", got %q", got)
+	}
+
+	// Alignment
+
+	x, _, _, _ := f.GetFormItem(0).GetRect()
+	input := f.GetFormItem(0).(*InputField)
+	if input.fieldX != x+len("E-mail:")+1 {
+		t.Errorf("failed to align fields: expected field at %d, got %d", x+len("E-mail:")+1, input.fieldX)
+	}
+
+	// Items added later receive the suffix and removed items lose it.
+
+	f.AddInputField("Phone", "", 10, nil, nil)
+	if suffix := f.GetFormItem(3).(*InputField).getLabelSuffix(); suffix != ":" {
+		t.Errorf("failed to apply label suffix to added item: expected \":\", got %q", suffix)
+	}
+	f.RemoveFormItem(0)
+	if suffix := input.getLabelSuffix(); suffix != "" {
+		t.Errorf("failed to remove label suffix from removed item: got %q", suffix)
+	}
+	f.SetLabelSuffix("")
+	if suffix := f.GetFormItem(0).(*InputField).getLabelSuffix(); suffix != "" {
+		t.Errorf("failed to remove label suffix: got %q", suffix)
+	}
+}
+
+func TestFormItemHeight(t *testing.T) {
+	t.Parallel()
+
+	f := NewForm()
+	f.AddCheckBox("Terms", "I have read and agree to the terms and conditions", false, nil)
+	c := f.GetFormItem(0).(*CheckBox)
+	c.SetMessageWrap(true)
+	r := NewRadioGroup()
+	r.SetLabel("Size")
+	r.AddOption("Small")
+	r.AddOption("Medium")
+	r.AddOption("Large")
+	f.AddFormItem(r)
+	f.SetRect(0, 0, 30, 20)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	f.Draw(app.screen)
+
+	// Wrapped message
+
+	cx, cy, _, ch := c.GetRect()
+	if ch < 2 || ch != c.GetFieldHeight() {
+		t.Fatalf("failed to size wrapped CheckBox: expected height %d, got %d", c.GetFieldHeight(), ch)
+	}
+	c.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(cx+20, cy+1, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	if !c.IsChecked() {
+		t.Error("failed to toggle CheckBox by clicking a wrapped message row")
+	}
+
+	// Multiple rows
+
+	_, ry, _, rh := r.GetRect()
+	if rh != 3 {
+		t.Errorf("failed to size RadioGroup: expected height 3, got %d", rh)
+	} else if ry < cy+ch {
+		t.Errorf("failed to place RadioGroup below CheckBox: expected row >= %d, got %d", cy+ch, ry)
+	}
+}
@@ -2,15 +2,24 @@ package cview
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"math"
-	"regexp"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
 )
 
+// inputFieldKillRingSize is the maximum number of deleted texts which are
+// kept by an InputField to be yanked back.
+const inputFieldKillRingSize = 10
+
 // InputField is a one-line box (three lines if there is a title) where the
 // user can enter text. Use SetAcceptanceFunc() to accept or reject input,
 // SetChangedFunc() to listen for changes, and SetMaskCharacter() to hide input
@@ -29,18 +38,66 @@ import (
 //   - Ctrl-K: Delete from the cursor to the end of the line.
 //   - Ctrl-W: Delete the last word before the cursor.
 //   - Ctrl-U: Delete the entire line.
+//   - Ctrl-T: Transpose the characters before and at the cursor.
+//   - Ctrl-Y: Insert the most recently deleted text (deleted via Ctrl-K, Ctrl-W
+//     or Ctrl-U).
+//   - Alt-y: Directly after Ctrl-Y, replace the inserted text with the
+//     previously deleted text.
+//   - Alt-u, Alt-l, Alt-c: Uppercase, lowercase or capitalize the text from
+//     the cursor to the end of the next word.
+//   - Insert: Toggle between insert and overwrite mode.
+//   - Ctrl-Space: Show all autocomplete entries. The autocomplete function is
+//     called with an empty text, for which it should return all entries.
+//
+// Except for Left arrow, Right arrow, Backspace and Delete, these keybindings
+// may be changed via the MoveLineStart, MoveLineEnd, MoveWordLeft,
+// MoveWordRight, DeleteToEnd, DeleteWord, DeleteAll, TransposeCharacters, Yank,
+// YankPop, UppercaseWord, LowercaseWord, CapitalizeWord, ToggleOverwrite and
+// ShowAutocomplete fields of Keys.
+//
+// While the autocomplete list is shown, Up, Down, Tab and Backtab select the
+// previous or next entry, Home and End select the first or last entry and
+// PageUp and PageDown move the selection by one page. Right arrow at the end of
+// the text appends the suggested completion (see SetAcceptSuggestionKey).
+// Enter commits the selected entry, as does the key set via
+// SetAutocompleteCommitKey.
 type InputField struct {
 	*Box
 
 	// The text that was entered.
 	text []byte
 
+	// The text against which the entered text is compared to determine
+	// whether the input field has been modified.
+	initialText []byte
+
 	// The text to be displayed before the input area.
 	label []byte
 
 	// The text to be displayed in the input area when "text" is empty.
 	placeholder []byte
 
+	// Whether the placeholder is hidden while the input field is focused.
+	hidePlaceholderOnFocus bool
+
+	// Placeholder texts which are cycled through while the input area is empty
+	// and the field is not focused.
+	placeholderRotation [][]byte
+
+	// The interval at which the rotating placeholder texts are cycled.
+	placeholderRotationInterval time.Duration
+
+	// The index of the rotating placeholder text which is currently shown.
+	placeholderRotationIndex int
+
+	// A channel which is closed to stop cycling placeholder texts. It is nil
+	// when placeholder texts are not being cycled.
+	placeholderRotationStop chan struct{}
+
+	// Whether or not the rotating placeholder text was drawn since it was last
+	// cycled.
+	placeholderRotationDrawn bool
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -80,24 +137,88 @@ type InputField struct {
 	// The text color of the suggestion.
 	autocompleteSuggestionTextColor tcell.Color
 
+	// Whether the suggestion is shown (masked) when a mask character is set.
+	showSuggestionWhenMasked bool
+
+	// Whether text deleted from a masked field is stored in the kill ring.
+	allowCopyWhenMasked bool
+
 	// The text color of the note below the input field.
 	fieldNoteTextColor tcell.Color
 
 	// The note to show below the input field.
 	fieldNote []byte
 
+	// Whether the input field is in an error state.
+	errorState bool
+
+	// The background color of the input area in the error state.
+	errorColor tcell.Color
+
+	// Whether the error state is cleared when the text changes.
+	clearErrorOnChange bool
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
 
+	// The text drawn after the label, e.g. a colon.
+	labelSuffix string
+
+	// Whether the label suffix was set via SetLabelSuffix. The suffix of a
+	// form is then ignored.
+	labelSuffixSet bool
+
+	// Where the label is drawn relative to the input area.
+	labelPosition LabelPosition
+
 	// The screen width of the input area. A value of 0 means extend as much as
 	// possible.
 	fieldWidth int
 
+	// The alignment of the placeholder and entered text within the input area
+	// (AlignLeft, AlignCenter or AlignRight).
+	fieldAlign int
+
+	// The rune drawn at the edges of the field when the text does not fit. A
+	// value of 0 disables the indicators.
+	overflowIndicator rune
+
+	// Whether or not a button which clears the text is drawn at the right edge
+	// of the field.
+	showClearButton bool
+
+	// The symbol of the clear button.
+	clearButtonRune rune
+
+	// An optional function which rates the strength of the entered text. It is
+	// shown below masked fields.
+	strengthFunc func(text string) (score int, label string)
+
+	// The colors of the strength meter, indexed by score, and the color of its
+	// unfilled part.
+	strengthColors     []tcell.Color
+	strengthEmptyColor tcell.Color
+
+	// The symbols of the filled and the unfilled part of the strength meter.
+	strengthRune, strengthEmptyRune rune
+
 	// A character to mask entered text (useful for password fields). A value of 0
 	// disables masking.
 	maskCharacter rune
 
+	// The duration for which the most recently entered character is shown
+	// before it is masked. A value of 0 masks characters immediately.
+	maskRevealDuration time.Duration
+
+	// The byte index and length of the character which is currently revealed
+	// in a masked field. A length of 0 means no character is revealed.
+	maskRevealPos, maskRevealLen int
+
+	// Incremented each time a character is revealed so that outdated reveal
+	// timers are ignored.
+	maskRevealGeneration int
+
 	// The cursor position as a byte index into the text string.
 	cursorPos int
 
@@ -108,6 +229,44 @@ type InputField struct {
 	// the main text is used.
 	autocomplete func(text string) []*ListItem
 
+	// An optional asynchronous autocomplete function which is used instead of
+	// the autocomplete function.
+	autocompleteAsync func(ctx context.Context, text string, callback func(entries []*ListItem))
+
+	// Cancels the context of the pending asynchronous autocomplete request.
+	autocompleteCancel context.CancelFunc
+
+	// An optional function which is called for each autocomplete entry as the
+	// autocomplete list is built.
+	autocompleteStyler func(index int, item *ListItem)
+
+	// An optional function which is called when the autocomplete list is
+	// created.
+	autocompleteConfigure func(l *List)
+
+	// The maximum number of rows of the autocomplete list. A value of 0 means
+	// no limit.
+	autocompleteMaxHeight int
+
+	// Whether the number of autocomplete entries which do not fit into the
+	// autocomplete list is shown below it.
+	autocompleteShowOverflowCount bool
+
+	// The text color of the number of hidden autocomplete entries.
+	autocompleteOverflowTextColor tcell.Color
+
+	// Whether or not the autocomplete drop-down is shown. When false, only the
+	// suggested completion of the first autocomplete entry is shown.
+	autocompleteShowList bool
+
+	// The duration for which input must settle before the autocomplete
+	// callback is invoked. A value of 0 invokes it immediately.
+	autocompleteDebounce time.Duration
+
+	// Incremented each time the autocomplete callback is scheduled so that
+	// outdated debounce timers are ignored.
+	autocompleteGeneration int
+
 	// The List object which shows the selectable autocomplete entries. If not
 	// nil, the list's main texts represent the current autocomplete entries.
 	autocompleteList *List
@@ -115,27 +274,148 @@ type InputField struct {
 	// The suggested completion of the current autocomplete ListItem.
 	autocompleteListSuggestion []byte
 
-	// An optional function which may reject the last character that was entered.
-	accept func(text string, ch rune) bool
+	// The key which appends the suggested completion to the text when the
+	// cursor is at the end of the text.
+	acceptSuggestionKey tcell.Key
+
+	// An additional key which commits the selected autocomplete entry, or
+	// KeyNUL.
+	autocompleteCommitKey tcell.Key
+
+	// An optional function which may reject the last character that was entered
+	// and return a message explaining the rejection.
+	accept func(text string, ch rune) (bool, string)
+
+	// An optional function which reformats the text when the input is
+	// finished.
+	format func(text string) string
+
+	// Whether the field note shows the message of a rejected character, and
+	// the field note which it replaced. The replaced note is restored when the
+	// next character is accepted.
+	rejectionNote  bool
+	savedFieldNote []byte
 
 	// An optional function which is called when the input has changed.
-	changed func(text string)
+	changed func(text string, reason ChangeReason)
+
+	// An optional function which is called when the input field receives
+	// focus.
+	focusFunc func()
+
+	// An optional function which is called when the input field loses focus.
+	blurFunc func()
+
+	// Whether the done and finished handlers are called when the input field
+	// loses focus without a key having finished the input.
+	finishOnBlur bool
+
+	// Whether the last key processed by the input field finished the input.
+	finishedByKey bool
+
+	// Whether or not the text is restricted to the autocomplete entries.
+	restrict bool
+
+	// The action taken when the text does not match any autocomplete entry.
+	restrictAction RestrictAction
+
+	// The last text which matched an autocomplete entry or which was set by
+	// the application.
+	lastValidText string
+
+	// An optional function which is called when the text does not match any
+	// autocomplete entry.
+	invalidEntry func(text string)
+
+	// Whether or not the entire text is selected when the input field receives
+	// focus.
+	selectAllOnFocus bool
+
+	// Whether or not the entire text is selected. The selected text is replaced
+	// by the next character entered.
+	selectedAll bool
+
+	// Whether or not Vim-style modal editing is enabled.
+	vimMode bool
+
+	// The current edit mode when Vim-style modal editing is enabled.
+	editMode EditMode
+
+	// An optional function which is called when the edit mode changes.
+	editModeChanged func(mode EditMode)
 
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, enter, or escape).
 	done func(tcell.Key)
 
+	// Whether or not the Enter key is ignored by the input field.
+	ignoreEnter bool
+
+	// The amount by which a numeric value is incremented or decremented using
+	// the Up and Down keys. A value of 0 disables numeric stepping.
+	numericStep float64
+
+	// The minimum and maximum numeric value when stepping.
+	numericMin, numericMax float64
+
+	// Whether or not numeric values are limited to numericMin and numericMax.
+	numericBounded bool
+
 	// A callback function set by the Form class and called when the user leaves
 	// this form item.
 	finished func(tcell.Key)
 
+	// A callback function set by the Form class and called when the value of
+	// this form item changes.
+	formChanged func()
+
+	// An optional function which is called from another goroutine when the
+	// input field needs to be redrawn (e.g. when cycling placeholder texts).
+	redraw func()
+
 	// The x-coordinate of the input field as determined during the last call to Draw().
 	fieldX int
 
+	// The y-coordinate and the width of the input field as determined during
+	// the last call to Draw().
+	fieldY, fieldScreenWidth int
+
+	// The x-coordinate of the clear button as determined during the last call
+	// to Draw(), or -1 if it was not drawn.
+	clearButtonX int
+
+	// The screen x position of the first cell of the entered text, which differs
+	// from fieldX when the text is aligned.
+	textX int
+
+	// The screen position of the cursor as determined during the last call to Draw().
+	cursorScreenX, cursorScreenY int
+
 	// The number of bytes of the text string skipped ahead while drawing.
 	offset int
 
+	// The most recently deleted texts, the most recent one last.
+	killRing [][]byte
+
+	// Whether the last key yanked text, the byte range of the yanked text and
+	// the position of the yanked text in the kill ring counting from the end.
+	yanked             bool
+	yankStart, yankEnd int
+	yankIndex          int
+
+	// Whether the offset was set via ScrollTo, and the cursor position at that
+	// time. The offset is kept until the cursor moves.
+	scrolled        bool
+	scrollCursorPos int
+
+	// Whether typed characters replace the character under the cursor instead
+	// of being inserted.
+	overwrite bool
+
+	// The style of the cursor in overwrite mode.
+	overwriteCursorStyle tcell.CursorStyle
+
 	sync.RWMutex
 }
 
@@ -154,24 +434,219 @@ func NewInputField() *InputField {
 		autocompleteListSelectedTextColor:       Styles.PrimitiveBackgroundColor,
 		autocompleteListSelectedBackgroundColor: Styles.PrimaryTextColor,
 		autocompleteSuggestionTextColor:         Styles.ContrastSecondaryTextColor,
+		autocompleteOverflowTextColor:           Styles.ContrastSecondaryTextColor,
+		autocompleteShowList:                    true,
+		acceptSuggestionKey:                     tcell.KeyRight,
+		overwriteCursorStyle:                    tcell.CursorStyleSteadyBlock,
 		fieldNoteTextColor:                      Styles.SecondaryTextColor,
 		labelColorFocused:                       ColorUnset,
 		placeholderTextColorFocused:             ColorUnset,
+		errorColor:                              Styles.InputFieldErrorColor,
+		clearErrorOnChange:                      true,
+		clearButtonX:                            -1,
+		clearButtonRune:                         Styles.InputFieldClearButtonRune,
+		strengthColors:                          Styles.InputFieldStrengthColors,
+		strengthEmptyColor:                      Styles.InputFieldStrengthEmptyColor,
+		strengthRune:                            Styles.InputFieldStrengthRune,
+		strengthEmptyRune:                       Styles.InputFieldStrengthEmptyRune,
 	}
 }
 
-// SetText sets the current text of the input field.
+// NewPasswordField returns a new input field which masks its text using the
+// given character (e.g. '*'). Like any masked field, it does not show
+// autocomplete suggestions (see SetShowSuggestionWhenMasked) and does not copy
+// deleted text into its kill ring (see SetAllowCopyWhenMasked).
+func NewPasswordField(mask rune) *InputField {
+	i := NewInputField()
+	i.SetMaskCharacter(mask)
+	return i
+}
+
+// ChangeReason describes why the text of an InputField has changed.
+type ChangeReason int
+
+// Reasons for changes to the text of an InputField.
+const (
+	ChangeReasonUserInput    ChangeReason = iota // The user entered text.
+	ChangeReasonPaste                            // Text was pasted.
+	ChangeReasonProgrammatic                     // The text was set by the application.
+	ChangeReasonAutocomplete                     // An autocomplete entry was selected.
+	ChangeReasonDelete                           // The user deleted text.
+)
+
+// EditMode describes the edit mode of an InputField with Vim-style modal
+// editing enabled.
+type EditMode int
+
+// Edit modes of an InputField.
+const (
+	EditModeInsert EditMode = iota // Keys are processed as usual.
+	EditModeNormal                 // Keys are interpreted as commands.
+)
+
+// RestrictAction describes how an InputField which is restricted to its
+// autocomplete entries handles text which does not match any entry.
+type RestrictAction int
+
+// Actions taken on text which does not match any autocomplete entry.
+const (
+	RestrictRevert        RestrictAction = iota // Revert to the last valid text.
+	RestrictSelectClosest                       // Select the first autocomplete entry.
+)
+
+// InputFieldState is a snapshot of the editing state of an InputField.
+type InputFieldState struct {
+	// The text that was entered.
+	Text string
+
+	// The cursor position as a byte index into the text.
+	CursorPosition int
+
+	// Whether the entire text is selected.
+	SelectedAll bool
+
+	// The byte index of the first character shown when the text does not fit
+	// into the input field.
+	Offset int
+}
+
+// inputFieldMarshaledState is the state of an InputField as persisted by
+// MarshalState.
+type inputFieldMarshaledState struct {
+	Text           string `json:"text"`
+	CursorPosition int    `json:"cursor"`
+}
+
+// SetText sets the current text of the input field. The changed handler is
+// only called when the text differs from the current text.
 func (i *InputField) SetText(text string) {
+	i.setTextWithCursor(text, len(text), ChangeReasonProgrammatic)
+}
+
+// SetTextWithCursor sets the current text of the input field and moves the
+// cursor to the given byte index within the new text. Cursor positions which
+// are out of range or not on a rune boundary are clamped to the closest
+// preceding valid position. The changed handler is only called when the text
+// differs from the current text.
+func (i *InputField) SetTextWithCursor(text string, cursorPos int) {
+	i.setTextWithCursor(text, cursorPos, ChangeReasonProgrammatic)
+}
+
+// clampTextPosition returns the closest valid byte index within text at or
+// before pos.
+func clampTextPosition(text string, pos int) int {
+	if pos < 0 {
+		pos = 0
+	} else if pos > len(text) {
+		pos = len(text)
+	}
+	for pos > 0 && pos < len(text) && !utf8.RuneStart(text[pos]) {
+		pos--
+	}
+	return pos
+}
+
+func (i *InputField) setTextWithCursor(text string, cursorPos int, reason ChangeReason) {
 	i.Lock()
 
+	unchanged := text == string(i.text)
+
 	i.text = []byte(text)
-	i.cursorPos = len(text)
-	if i.changed != nil {
-		i.Unlock()
-		i.changed(text)
-	} else {
-		i.Unlock()
+	i.cursorPos = clampTextPosition(text, cursorPos)
+	i.lastValidText = text
+	i.maskRevealLen = 0
+	i.selectedAll = false
+	i.yanked = false
+	if !unchanged && i.clearErrorOnChange {
+		i.errorState = false
+	}
+	changed, formChanged := i.changed, i.formChanged
+	i.Unlock()
+
+	if unchanged {
+		return
+	}
+	if changed != nil {
+		changed(text, reason)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// GetState returns a snapshot of the text, the cursor position, the selection
+// and the scroll offset of the input field. Unlike separate calls to GetText
+// and GetCursorPosition, the snapshot is taken atomically.
+func (i *InputField) GetState() InputFieldState {
+	i.RLock()
+	defer i.RUnlock()
+
+	return InputFieldState{
+		Text:           string(i.text),
+		CursorPosition: i.cursorPos,
+		SelectedAll:    i.selectedAll,
+		Offset:         i.offset,
+	}
+}
+
+// SetState restores a snapshot previously returned by GetState. The cursor
+// position and the offset are clamped to the text. The changed handler is
+// only called when the text differs from the current text.
+func (i *InputField) SetState(state InputFieldState) {
+	i.Lock()
+
+	text := state.Text
+	unchanged := text == string(i.text)
+
+	i.text = []byte(text)
+	i.cursorPos = clampTextPosition(text, state.CursorPosition)
+	i.offset = clampTextPosition(text, state.Offset)
+	i.maskRevealLen = 0
+	i.selectedAll = state.SelectedAll && len(text) > 0
+	if !unchanged && i.clearErrorOnChange {
+		i.errorState = false
+	}
+	changed, formChanged := i.changed, i.formChanged
+	i.Unlock()
+
+	if unchanged {
+		return
+	}
+	if changed != nil {
+		changed(text, ChangeReasonProgrammatic)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// MarshalState returns the text and the cursor position of the input field
+// encoded as JSON. This implements StateMarshaler.
+func (i *InputField) MarshalState() ([]byte, error) {
+	i.RLock()
+	state := inputFieldMarshaledState{
+		Text:           string(i.text),
+		CursorPosition: i.cursorPos,
+	}
+	i.RUnlock()
+
+	return json.Marshal(state)
+}
+
+// UnmarshalState restores the text and the cursor position of the input field
+// from JSON returned by MarshalState. The changed handler is only called when
+// the text differs from the current text. This implements StateMarshaler.
+func (i *InputField) UnmarshalState(data []byte) error {
+	var state inputFieldMarshaledState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
 	}
+
+	i.SetState(InputFieldState{
+		Text:           state.Text,
+		CursorPosition: state.CursorPosition,
+	})
+	return nil
 }
 
 // GetText returns the current text of the input field.
@@ -182,7 +657,153 @@ func (i *InputField) GetText() string {
 	return string(i.text)
 }
 
-// SetLabel sets the text to be displayed before the input area.
+// GetFieldValue returns the text of the input field. This implements
+// FormItem.
+func (i *InputField) GetFieldValue() string {
+	return i.GetText()
+}
+
+// SetFieldValue sets the text of the input field (see SetText). This
+// implements FormValuer.
+func (i *InputField) SetFieldValue(value string) error {
+	i.SetText(value)
+	return nil
+}
+
+// GetRuneCount returns the number of runes in the current text of the input
+// field.
+func (i *InputField) GetRuneCount() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return utf8.RuneCount(i.text)
+}
+
+// GetTextWidth returns the screen width of the current text of the input
+// field, accounting for wide characters and combining marks. Masked text is
+// measured as entered, not as displayed.
+func (i *InputField) GetTextWidth() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	var width int
+	iterateString(string(i.text), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		width += screenWidth
+		return false
+	})
+	return width
+}
+
+// GetByteLength returns the length of the current text of the input field in
+// bytes.
+func (i *InputField) GetByteLength() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return len(i.text)
+}
+
+// InsertText inserts the given text at the cursor position as if it was pasted
+// by the user. When the entire text is selected, it is replaced instead.
+// Control characters and characters rejected by the acceptance function are
+// skipped. The changed handler and Autocomplete are called once after all
+// characters have been inserted.
+func (i *InputField) InsertText(text string) {
+	i.Lock()
+
+	currentText, currentCursorPos := i.text, i.cursorPos
+	selectedAll := i.selectedAll
+	i.selectedAll = false
+	if selectedAll {
+		i.text, i.cursorPos = nil, 0
+	}
+	i.insertText(text)
+	if selectedAll && len(i.text) == 0 {
+		// Keep the selected text when no character was accepted.
+		i.text, i.cursorPos, i.selectedAll = currentText, currentCursorPos, true
+	}
+	i.maskRevealLen = 0
+
+	newText := i.text
+	if i.clearErrorOnChange && !bytes.Equal(newText, currentText) {
+		i.errorState = false
+	}
+	changed, formChanged := i.changed, i.formChanged
+	i.Unlock()
+
+	if bytes.Equal(newText, currentText) {
+		return
+	}
+	i.autocompleteInput()
+	if changed != nil {
+		changed(string(newText), ChangeReasonPaste)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
+// insertText inserts the given text at the cursor position, skipping control
+// characters and characters rejected by the acceptance function. The caller
+// must hold the lock.
+func (i *InputField) insertText(text string) {
+	for _, r := range text {
+		if unicode.IsControl(r) {
+			continue
+		}
+		newText := append(append(i.text[:i.cursorPos:i.cursorPos], []byte(string(r))...), i.text[i.cursorPos:]...)
+		if i.accept != nil {
+			if ok, _ := i.accept(string(newText), r); !ok {
+				continue
+			}
+		}
+		i.text = newText
+		i.cursorPos += len(string(r))
+	}
+}
+
+// kill adds the given deleted text to the kill ring. The caller must hold the
+// lock.
+func (i *InputField) kill(text []byte) {
+	if len(text) == 0 || i.maskCharacter != 0 && !i.allowCopyWhenMasked {
+		return
+	}
+	i.killRing = append(i.killRing, append([]byte(nil), text...))
+	if len(i.killRing) > inputFieldKillRingSize {
+		i.killRing = i.killRing[1:]
+	}
+}
+
+// SetInitialText sets the text against which the current text is compared to
+// determine whether the input field has been modified. The current text is not
+// changed. See IsDirty.
+func (i *InputField) SetInitialText(text string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.initialText = []byte(text)
+}
+
+// IsDirty returns whether the current text differs from the initial text set
+// via SetInitialText or ResetDirty.
+func (i *InputField) IsDirty() bool {
+	i.RLock()
+	defer i.RUnlock()
+
+	return !bytes.Equal(i.text, i.initialText)
+}
+
+// ResetDirty sets the initial text to the current text, e.g. after the text
+// has been saved.
+func (i *InputField) ResetDirty() {
+	i.Lock()
+	defer i.Unlock()
+
+	i.initialText = append([]byte(nil), i.text...)
+}
+
+// SetLabel sets the text to be displayed before the input area. The label may
+// contain color tags, e.g. "[red]*[-]Name" to mark a required field.
 func (i *InputField) SetLabel(label string) {
 	i.Lock()
 	defer i.Unlock()
@@ -207,102 +828,277 @@ func (i *InputField) SetLabelWidth(width int) {
 	i.labelWidth = width
 }
 
-// SetPlaceholder sets the text to be displayed when the input text is empty.
-func (i *InputField) SetPlaceholder(text string) {
+// SetLabelSuffix sets a text which is drawn after the label, e.g. ":". It
+// overrides the label suffix of the form the input field is added to.
+func (i *InputField) SetLabelSuffix(suffix string) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.placeholder = []byte(text)
+	i.labelSuffix = suffix
+	i.labelSuffixSet = true
 }
 
-// SetLabelColor sets the color of the label.
-func (i *InputField) SetLabelColor(color tcell.Color) {
+// setFormLabelSuffix sets the label suffix of the form this input field belongs
+// to. It is ignored when a suffix was set via SetLabelSuffix.
+func (i *InputField) setFormLabelSuffix(suffix string) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.labelColor = color
+	if !i.labelSuffixSet {
+		i.labelSuffix = suffix
+	}
 }
 
-// SetLabelColorFocused sets the color of the label when focused.
-func (i *InputField) SetLabelColorFocused(color tcell.Color) {
-	i.Lock()
-	defer i.Unlock()
+// getLabelSuffix returns the text drawn after the label.
+func (i *InputField) getLabelSuffix() string {
+	i.RLock()
+	defer i.RUnlock()
 
-	i.labelColorFocused = color
+	return i.labelSuffix
 }
 
-// SetFieldBackgroundColor sets the background color of the input area.
-func (i *InputField) SetFieldBackgroundColor(color tcell.Color) {
+// SetLabelPosition sets where the label is drawn. When set to LabelAbove, the
+// label is drawn on its own line and the input area spans the full width
+// below it. The label width is then ignored.
+func (i *InputField) SetLabelPosition(position LabelPosition) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldBackgroundColor = color
+	i.labelPosition = position
 }
 
-// SetFieldBackgroundColorFocused sets the background color of the input area
-// when focused.
-func (i *InputField) SetFieldBackgroundColorFocused(color tcell.Color) {
-	i.Lock()
-	defer i.Unlock()
+// GetLabelPosition returns where the label is drawn.
+func (i *InputField) GetLabelPosition() LabelPosition {
+	i.RLock()
+	defer i.RUnlock()
 
-	i.fieldBackgroundColorFocused = color
+	return i.labelPosition
 }
 
-// SetFieldTextColor sets the text color of the input area.
-func (i *InputField) SetFieldTextColor(color tcell.Color) {
+// SetPlaceholder sets the text to be displayed when the input text is empty.
+// Like the label, the placeholder may contain color tags. Use Escape to print
+// text containing square brackets as is.
+func (i *InputField) SetPlaceholder(text string) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldTextColor = color
+	i.placeholder = []byte(text)
 }
 
-// SetFieldTextColorFocused sets the text color of the input area when focused.
-func (i *InputField) SetFieldTextColorFocused(color tcell.Color) {
+// SetHidePlaceholderOnFocus sets a flag which determines whether the
+// placeholder is hidden while the input field is focused. By default, the
+// placeholder is shown until text is entered.
+func (i *InputField) SetHidePlaceholderOnFocus(hide bool) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldTextColorFocused = color
+	i.hidePlaceholderOnFocus = hide
 }
 
-// SetPlaceholderTextColor sets the text color of placeholder text.
-func (i *InputField) SetPlaceholderTextColor(color tcell.Color) {
+// SetOverwriteMode sets whether typed characters replace the character under
+// the cursor (overwrite mode) instead of being inserted before it (insert
+// mode). Characters typed at the end of the text are always appended. The user
+// may toggle the mode via the Insert key.
+func (i *InputField) SetOverwriteMode(overwrite bool) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.placeholderTextColor = color
+	i.overwrite = overwrite
 }
 
-// SetPlaceholderTextColorFocused sets the text color of placeholder text when
-// focused.
-func (i *InputField) SetPlaceholderTextColorFocused(color tcell.Color) {
-	i.Lock()
-	defer i.Unlock()
+// GetOverwriteMode returns whether the input field is in overwrite mode.
+func (i *InputField) GetOverwriteMode() bool {
+	i.RLock()
+	defer i.RUnlock()
 
-	i.placeholderTextColorFocused = color
+	return i.overwrite
 }
 
-// SetAutocompleteListTextColor sets the text color of the ListItems.
-func (i *InputField) SetAutocompleteListTextColor(color tcell.Color) {
+// SetOverwriteCursorStyle sets the style of the cursor shown while the input
+// field is in overwrite mode. The default is a steady block. In insert mode,
+// the style set via SetCursorStyle is used.
+func (i *InputField) SetOverwriteCursorStyle(style tcell.CursorStyle) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListTextColor = color
+	i.overwriteCursorStyle = style
 }
 
-// SetAutocompleteListBackgroundColor sets the background color of the
-// autocomplete list.
-func (i *InputField) SetAutocompleteListBackgroundColor(color tcell.Color) {
+// SetPlaceholderRotation sets placeholder texts which are cycled through at
+// the given interval while the input area is empty and the field is not
+// focused. The placeholder text set via SetPlaceholder is shown while the field
+// is focused.
+//
+// Placeholder texts are cycled in a separate goroutine, which is started when
+// the rotating placeholder text is drawn. A redraw handler must be set via
+// SetRedrawFunc for the changes to become visible. The goroutine ends when
+// the field receives focus, when text is entered or when the field was not
+// drawn during an interval, e.g. because it was removed from the layout, and
+// is started again by the next draw. Provide an empty slice to stop cycling
+// placeholder texts immediately.
+func (i *InputField) SetPlaceholderRotation(placeholders []string, interval time.Duration) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListBackgroundColor = color
+	i.stopPlaceholderRotation()
+
+	i.placeholderRotation = nil
+	for _, placeholder := range placeholders {
+		i.placeholderRotation = append(i.placeholderRotation, []byte(placeholder))
+	}
+	i.placeholderRotationInterval = interval
+	i.placeholderRotationIndex = 0
 }
 
-// SetAutocompleteListSelectedTextColor sets the text color of the selected
-// ListItem.
-func (i *InputField) SetAutocompleteListSelectedTextColor(color tcell.Color) {
-	i.Lock()
-	defer i.Unlock()
+// startPlaceholderRotation starts cycling placeholder texts if rotating
+// placeholder texts are configured and the input area is empty and not
+// focused. It is called when the placeholder is drawn. The caller must hold
+// the lock.
+func (i *InputField) startPlaceholderRotation() {
+	if len(i.placeholderRotation) < 2 || i.placeholderRotationInterval <= 0 || i.placeholderRotationStop != nil || len(i.text) > 0 || i.GetFocusable().HasFocus() {
+		return
+	}
+
+	stop := make(chan struct{})
+	i.placeholderRotationStop = stop
+	go i.rotatePlaceholder(stop, i.placeholderRotationInterval)
+}
+
+// stopPlaceholderRotation stops cycling placeholder texts. The caller must hold
+// the lock.
+func (i *InputField) stopPlaceholderRotation() {
+	if i.placeholderRotationStop == nil {
+		return
+	}
+	close(i.placeholderRotationStop)
+	i.placeholderRotationStop = nil
+}
+
+// rotatePlaceholder cycles placeholder texts until the stop channel is closed,
+// the field is no longer empty and unfocused or the placeholder was not drawn
+// since it was last cycled.
+func (i *InputField) rotatePlaceholder(stop chan struct{}, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+
+		i.Lock()
+		select {
+		case <-stop:
+			i.Unlock()
+			return
+		default:
+		}
+		if len(i.text) > 0 || i.GetFocusable().HasFocus() || !i.placeholderRotationDrawn {
+			i.stopPlaceholderRotation()
+			i.Unlock()
+			return
+		}
+		i.placeholderRotationDrawn = false
+		i.placeholderRotationIndex = (i.placeholderRotationIndex + 1) % len(i.placeholderRotation)
+		redraw := i.redraw
+		i.Unlock()
+
+		if redraw != nil {
+			redraw()
+		}
+	}
+}
+
+// SetLabelColor sets the color of the label.
+func (i *InputField) SetLabelColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.labelColor = color
+}
+
+// SetLabelColorFocused sets the color of the label when focused.
+func (i *InputField) SetLabelColorFocused(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.labelColorFocused = color
+}
+
+// SetFieldBackgroundColor sets the background color of the input area.
+func (i *InputField) SetFieldBackgroundColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldBackgroundColor = color
+}
+
+// SetFieldBackgroundColorFocused sets the background color of the input area
+// when focused.
+func (i *InputField) SetFieldBackgroundColorFocused(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldBackgroundColorFocused = color
+}
+
+// SetFieldTextColor sets the text color of the input area.
+func (i *InputField) SetFieldTextColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldTextColor = color
+}
+
+// SetFieldTextColorFocused sets the text color of the input area when focused.
+func (i *InputField) SetFieldTextColorFocused(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldTextColorFocused = color
+}
+
+// SetPlaceholderTextColor sets the text color of placeholder text.
+func (i *InputField) SetPlaceholderTextColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.placeholderTextColor = color
+}
+
+// SetPlaceholderTextColorFocused sets the text color of placeholder text when
+// focused.
+func (i *InputField) SetPlaceholderTextColorFocused(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.placeholderTextColorFocused = color
+}
+
+// SetAutocompleteListTextColor sets the text color of the ListItems.
+func (i *InputField) SetAutocompleteListTextColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteListTextColor = color
+}
+
+// SetAutocompleteListBackgroundColor sets the background color of the
+// autocomplete list.
+func (i *InputField) SetAutocompleteListBackgroundColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteListBackgroundColor = color
+}
+
+// SetAutocompleteListSelectedTextColor sets the text color of the selected
+// ListItem.
+func (i *InputField) SetAutocompleteListSelectedTextColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
 
 	i.autocompleteListSelectedTextColor = color
 }
@@ -325,6 +1121,28 @@ func (i *InputField) SetAutocompleteSuggestionTextColor(color tcell.Color) {
 	i.autocompleteSuggestionTextColor = color
 }
 
+// SetShowSuggestionWhenMasked sets a flag which determines whether the
+// autocomplete suggestion is shown in fields with a mask character (see
+// SetMaskCharacter). The suggestion is then drawn using the mask character and
+// may be accepted like in unmasked fields. This is disabled by default.
+func (i *InputField) SetShowSuggestionWhenMasked(show bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.showSuggestionWhenMasked = show
+}
+
+// SetAllowCopyWhenMasked sets a flag which determines whether text deleted
+// from a field with a mask character (see SetMaskCharacter) is copied into the
+// kill ring, from which it may be yanked back via Ctrl+Y. This is disabled by
+// default so that passwords are not kept in memory after they were deleted.
+func (i *InputField) SetAllowCopyWhenMasked(allow bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.allowCopyWhenMasked = allow
+}
+
 // SetFieldNoteTextColor sets the text color of the note.
 func (i *InputField) SetFieldNoteTextColor(color tcell.Color) {
 	i.Lock()
@@ -333,6 +1151,44 @@ func (i *InputField) SetFieldNoteTextColor(color tcell.Color) {
 	i.fieldNoteTextColor = color
 }
 
+// SetError sets whether the input field is in an error state, e.g. when the
+// input is invalid. While in the error state, the input area is drawn with the
+// error color as its background, whether or not the field has focus. The
+// error state is cleared when the text changes unless disabled via
+// SetClearErrorOnChange.
+func (i *InputField) SetError(hasError bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.errorState = hasError
+}
+
+// HasError returns whether the input field is in an error state.
+func (i *InputField) HasError() bool {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.errorState
+}
+
+// SetErrorColor sets the background color of the input area while the input
+// field is in an error state.
+func (i *InputField) SetErrorColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.errorColor = color
+}
+
+// SetClearErrorOnChange sets whether the error state is cleared when the text
+// changes. This is enabled by default.
+func (i *InputField) SetClearErrorOnChange(clear bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.clearErrorOnChange = clear
+}
+
 // SetFieldNote sets the text to show below the input field, e.g. when the
 // input is invalid.
 func (i *InputField) SetFieldNote(note string) {
@@ -340,6 +1196,8 @@ func (i *InputField) SetFieldNote(note string) {
 	defer i.Unlock()
 
 	i.fieldNote = []byte(note)
+	i.rejectionNote = false
+	i.savedFieldNote = nil
 }
 
 // ResetFieldNote sets the note to an empty string.
@@ -348,6 +1206,8 @@ func (i *InputField) ResetFieldNote() {
 	defer i.Unlock()
 
 	i.fieldNote = nil
+	i.rejectionNote = false
+	i.savedFieldNote = nil
 }
 
 // SetFieldWidth sets the screen width of the input area. A value of 0 means
@@ -359,6 +1219,16 @@ func (i *InputField) SetFieldWidth(width int) {
 	i.fieldWidth = width
 }
 
+// SetFieldAlign sets the alignment of the placeholder and entered text within
+// the input area. This must be either AlignLeft, AlignCenter, or AlignRight.
+// Text which does not fit within the input area is always left-aligned.
+func (i *InputField) SetFieldAlign(align int) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.fieldAlign = align
+}
+
 // GetFieldWidth returns this primitive's field width.
 func (i *InputField) GetFieldWidth() int {
 	i.RLock()
@@ -371,10 +1241,60 @@ func (i *InputField) GetFieldWidth() int {
 func (i *InputField) GetFieldHeight() int {
 	i.RLock()
 	defer i.RUnlock()
-	if len(i.fieldNote) == 0 {
-		return 1
+	height := 1
+	if i.labelPosition == LabelAbove {
+		height++
+	}
+	if i.showStrength() {
+		height++
+	}
+	if len(i.fieldNote) > 0 {
+		height += len(i.noteLines(i.noteWidth()))
+	}
+	return height
+}
+
+// showSuggestion returns whether the autocomplete suggestion is shown. The
+// caller must hold the lock.
+func (i *InputField) showSuggestion() bool {
+	return len(i.autocompleteListSuggestion) > 0 && (i.maskCharacter == 0 || i.showSuggestionWhenMasked)
+}
+
+// showStrength returns whether the strength meter is shown. The caller must
+// hold the lock.
+func (i *InputField) showStrength() bool {
+	return i.strengthFunc != nil && i.maskCharacter != 0 && len(i.strengthColors) > 0
+}
+
+// noteWidth returns the width available to the field note, which is the width
+// of the input area. The caller must hold the lock.
+func (i *InputField) noteWidth() int {
+	_, _, width, _ := i.GetInnerRect()
+	if i.labelPosition != LabelAbove {
+		labelWidth := i.labelWidth
+		if labelWidth == 0 {
+			labelWidth = TaggedTextWidth(suffixedLabel(i.label, i.labelSuffix))
+		}
+		width -= labelWidth
+	}
+	if i.fieldWidth > 0 && i.fieldWidth < width {
+		width = i.fieldWidth
+	}
+	return width
+}
+
+// noteLines returns the field note word-wrapped to the given width. The caller
+// must hold the lock.
+func (i *InputField) noteLines(width int) [][]byte {
+	if width < 1 {
+		return [][]byte{i.fieldNote}
+	}
+
+	var lines [][]byte
+	for _, line := range WordWrap(string(i.fieldNote), width) {
+		lines = append(lines, []byte(line))
 	}
-	return 2
+	return lines
 }
 
 // GetCursorPosition returns the cursor position.
@@ -385,6 +1305,47 @@ func (i *InputField) GetCursorPosition() int {
 	return i.cursorPos
 }
 
+// GetCursorScreenPosition returns the screen position of the cursor as
+// determined during the last call to Draw.
+func (i *InputField) GetCursorScreenPosition() (x, y int) {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.cursorScreenX, i.cursorScreenY
+}
+
+// GetFieldRect returns the screen rectangle of the input area, excluding the
+// label, as determined during the last call to Draw. This may be used to align
+// other primitives or decorations with the input area.
+func (i *InputField) GetFieldRect() (x, y, width, height int) {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.fieldX, i.fieldY, i.fieldScreenWidth, 1
+}
+
+// ScrollTo scrolls the text which does not fit into the input field so that
+// drawing starts at the given byte index, without moving the cursor. The
+// offset is clamped to the text. It is kept until the cursor is moved, after
+// which the text is scrolled to keep the cursor visible again.
+func (i *InputField) ScrollTo(offset int) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.offset = clampTextPosition(string(i.text), offset)
+	i.scrolled = true
+	i.scrollCursorPos = i.cursorPos
+}
+
+// GetScrollOffset returns the byte index of the first character shown when
+// the text does not fit into the input field.
+func (i *InputField) GetScrollOffset() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.offset
+}
+
 // SetCursorPosition sets the cursor position.
 func (i *InputField) SetCursorPosition(cursorPos int) {
 	i.Lock()
@@ -393,6 +1354,70 @@ func (i *InputField) SetCursorPosition(cursorPos int) {
 	i.cursorPos = cursorPos
 }
 
+// SetOverflowIndicator sets the rune which is drawn at the left edge of the
+// field when the text is scrolled and at the right edge of the field when more
+// text follows, e.g. '…'. While the text does not fit, one cell at each edge
+// of the field is reserved for the indicators. A value of 0 (the default)
+// disables the indicators.
+func (i *InputField) SetOverflowIndicator(indicator rune) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.overflowIndicator = indicator
+}
+
+// SetShowClearButton sets whether a button (see SetClearButtonRune) is drawn
+// in the last cell of the field while it contains text. Clicking the button
+// clears the text. The cell is reserved for the button and is not used to
+// display the text.
+func (i *InputField) SetShowClearButton(show bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.showClearButton = show
+}
+
+// SetClearButtonRune sets the symbol of the clear button (see
+// SetShowClearButton). The default is Styles.InputFieldClearButtonRune.
+func (i *InputField) SetClearButtonRune(rune rune) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.clearButtonRune = rune
+}
+
+// SetStrengthFunc sets a function which rates the strength of the entered
+// text, e.g. a password. While a mask character is set, a meter colored
+// according to the returned score and the returned label are shown below the
+// field, above any field note. The score ranges from 0 to the number of colors
+// set via SetStrengthColors minus one (0 to 4 by default, from red to green).
+func (i *InputField) SetStrengthFunc(handler func(text string) (score int, label string)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.strengthFunc = handler
+}
+
+// SetStrengthColors sets the colors of the strength meter (see
+// SetStrengthFunc), indexed by score, and the color of the unfilled part of
+// the meter. The meter is as wide as the number of colors.
+func (i *InputField) SetStrengthColors(colors []tcell.Color, emptyColor tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.strengthColors = colors
+	i.strengthEmptyColor = emptyColor
+}
+
+// SetStrengthRunes sets the symbols of the filled and the unfilled part of the
+// strength meter (see SetStrengthFunc).
+func (i *InputField) SetStrengthRunes(filled, empty rune) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.strengthRune, i.strengthEmptyRune = filled, empty
+}
+
 // SetMaskCharacter sets a character that masks user input on a screen. A value
 // of 0 disables masking.
 func (i *InputField) SetMaskCharacter(mask rune) {
@@ -402,6 +1427,47 @@ func (i *InputField) SetMaskCharacter(mask rune) {
 	i.maskCharacter = mask
 }
 
+// SetMaskRevealDuration sets the duration for which the most recently entered
+// character is shown in a masked field before it is replaced by the mask
+// character. Deleting text or moving the cursor masks the character
+// immediately. A value of 0 (the default) masks characters immediately.
+//
+// Because characters are masked by a timer, a redraw handler must be set via
+// SetRedrawFunc for the change to become visible without further input.
+func (i *InputField) SetMaskRevealDuration(d time.Duration) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.maskRevealDuration = d
+	i.maskRevealLen = 0
+}
+
+// revealMasked reveals the character at the given byte index in a masked field
+// and starts a timer which masks it again. The caller must hold the lock.
+func (i *InputField) revealMasked(pos, length int) {
+	if i.maskCharacter == 0 || i.maskRevealDuration <= 0 {
+		return
+	}
+
+	i.maskRevealPos, i.maskRevealLen = pos, length
+	i.maskRevealGeneration++
+	generation := i.maskRevealGeneration
+	time.AfterFunc(i.maskRevealDuration, func() {
+		i.Lock()
+		if i.maskRevealGeneration != generation || i.maskRevealLen == 0 {
+			i.Unlock()
+			return
+		}
+		i.maskRevealLen = 0
+		redraw := i.redraw
+		i.Unlock()
+
+		if redraw != nil {
+			redraw()
+		}
+	})
+}
+
 // SetAutocompleteFunc sets an autocomplete callback function which may return
 // ListItems to be selected from a drop-down based on the current text of the
 // input field. The drop-down appears only if len(entries) > 0. The callback is
@@ -411,39 +1477,293 @@ func (i *InputField) SetMaskCharacter(mask rune) {
 func (i *InputField) SetAutocompleteFunc(callback func(currentText string) (entries []*ListItem)) {
 	i.Lock()
 	i.autocomplete = callback
+	i.autocompleteAsync = nil
 	i.Unlock()
 
 	i.Autocomplete()
 }
 
-// Autocomplete invokes the autocomplete callback (if there is one). If the
-// length of the returned autocomplete entries slice is greater than 0, the
-// input field will present the user with a corresponding drop-down list the
-// next time the input field is drawn.
-//
-// It is safe to call this function from any goroutine. Note that the input
-// field is not redrawn automatically unless called from the main goroutine
-// (e.g. in response to events).
-func (i *InputField) Autocomplete() {
+// SetAutocompleteFuncAsync sets an autocomplete function which retrieves the
+// autocomplete entries in the background, e.g. from a network service. It is
+// used instead of the function set via SetAutocompleteFunc and is invoked in
+// a new goroutine with the current text. It should pass the entries to the
+// provided callback, which may be called from any goroutine. The context is
+// canceled when a newer request supersedes this one or the user presses
+// Escape, in which case the entries are discarded. Set a redraw function (see
+// SetRedrawFunc) to have the drop-down shown once the entries arrive.
+func (i *InputField) SetAutocompleteFuncAsync(callback func(ctx context.Context, currentText string, callback func(entries []*ListItem))) {
 	i.Lock()
-	if i.autocomplete == nil {
-		i.Unlock()
-		return
-	}
+	i.autocomplete = nil
+	i.autocompleteAsync = callback
 	i.Unlock()
 
-	// Do we have any autocomplete entries?
-	entries := i.autocomplete(string(i.text))
-	if len(entries) == 0 {
-		// No entries, no list.
-		i.Lock()
-		i.autocompleteList = nil
-		i.autocompleteListSuggestion = nil
-		i.Unlock()
-		return
+	i.Autocomplete()
+}
+
+// SetAutocompleteEntryStyler sets a function which is called for each
+// autocomplete entry as the autocomplete list is built. It receives the index
+// of the entry and the entry itself, which may be modified to style it (e.g.
+// via ListItem.SetMainTextColor) or to mark it as a non-selectable separator
+// (via ListItem.SetEnabled). Disabled entries are skipped when moving the
+// selection and are never accepted.
+func (i *InputField) SetAutocompleteEntryStyler(styler func(index int, item *ListItem)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteStyler = styler
+}
+
+// SetAutocompleteListConfigureFunc sets a function which is called right after
+// the autocomplete list is created, e.g. to change its scroll bar or to attach
+// a selected handler. The list's changed handler is used by the input field to
+// update the suggested completion and should not be replaced.
+func (i *InputField) SetAutocompleteListConfigureFunc(configure func(l *List)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteConfigure = configure
+}
+
+// GetAutocompleteList returns the autocomplete list, or nil when it is not
+// shown.
+func (i *InputField) GetAutocompleteList() *List {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.autocompleteList
+}
+
+// SetAutocompleteMaxHeight sets the maximum number of rows of the autocomplete
+// drop-down. When there are more entries, the drop-down is scrollable. A value
+// of 0 (the default) means no limit.
+func (i *InputField) SetAutocompleteMaxHeight(rows int) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteMaxHeight = rows
+}
+
+// SetAutocompleteShowOverflowCount sets a flag which determines whether the
+// last row of the autocomplete drop-down shows the number of entries which do
+// not fit into it (e.g. "… and 12 more") when the drop-down is scrollable.
+func (i *InputField) SetAutocompleteShowOverflowCount(show bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteShowOverflowCount = show
+}
+
+// SetAutocompleteOverflowTextColor sets the text color of the number of
+// autocomplete entries which do not fit into the drop-down (see
+// SetAutocompleteShowOverflowCount).
+func (i *InputField) SetAutocompleteOverflowTextColor(color tcell.Color) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteOverflowTextColor = color
+}
+
+// SetAutocompleteShowList sets whether the autocomplete drop-down is shown
+// (the default). When false, the suggested completion of the first
+// autocomplete entry is shown within the input field, but no drop-down is
+// presented.
+func (i *InputField) SetAutocompleteShowList(show bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteShowList = show
+}
+
+// SetAcceptSuggestionKey sets the key which appends the suggested completion of
+// the selected autocomplete entry to the text when the cursor is at the end of
+// the text. The autocomplete list remains open. When the cursor is not at the
+// end of the text, or no completion is suggested, the key is processed as
+// usual. The default is the right arrow key. Set to tcell.KeyNUL to disable.
+func (i *InputField) SetAcceptSuggestionKey(key tcell.Key) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.acceptSuggestionKey = key
+}
+
+// SetAutocompleteCommitKey sets a key which, while the autocomplete list is
+// shown, commits the selected entry and hides the list, like Enter does. This
+// is useful to let Tab commit the entry as in many editors. The key then no
+// longer selects entries; Up and Down still do. The default is tcell.KeyNUL,
+// in which case only Enter commits the selected entry.
+func (i *InputField) SetAutocompleteCommitKey(key tcell.Key) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteCommitKey = key
+}
+
+// SetAutocompleteDebounce sets the duration for which the text must remain
+// unchanged after user input before the autocomplete callback is invoked, so
+// that rapid keystrokes result in a single invocation. A value of 0 (the
+// default) invokes the callback immediately.
+//
+// Debounced callbacks are invoked from another goroutine. A redraw handler
+// must be set via SetRedrawFunc for the autocomplete entries to become visible
+// before the input field is drawn again.
+func (i *InputField) SetAutocompleteDebounce(d time.Duration) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.autocompleteDebounce = d
+}
+
+// autocompleteInput invokes Autocomplete after the text was changed by the
+// user, debouncing the invocation when a duration is set.
+func (i *InputField) autocompleteInput() {
+	i.Lock()
+	i.autocompleteGeneration++
+	generation := i.autocompleteGeneration
+	debounce := i.autocompleteDebounce
+	i.Unlock()
+
+	if debounce <= 0 {
+		i.Autocomplete()
+		return
+	}
+
+	time.AfterFunc(debounce, func() {
+		i.RLock()
+		outdated := i.autocompleteGeneration != generation
+		i.RUnlock()
+		if outdated {
+			return
+		}
+
+		i.Autocomplete()
+
+		i.RLock()
+		redraw := i.redraw
+		i.RUnlock()
+		if redraw != nil {
+			redraw()
+		}
+	})
+}
+
+// Autocomplete invokes the autocomplete callback (if there is one). If the
+// length of the returned autocomplete entries slice is greater than 0, the
+// input field will present the user with a corresponding drop-down list the
+// next time the input field is drawn.
+//
+// It is safe to call this function from any goroutine. Note that the input
+// field is not redrawn automatically unless called from the main goroutine
+// (e.g. in response to events).
+func (i *InputField) Autocomplete() {
+	i.runAutocomplete(false)
+}
+
+// ShowAutocomplete presents the given entries in the autocomplete list,
+// regardless of the current text, e.g. to implement a combo box. Any pending
+// autocompletion is discarded. Pass nil to hide the autocomplete list.
+//
+// It is safe to call this function from any goroutine. Note that the input
+// field is not redrawn automatically unless called from the main goroutine
+// (e.g. in response to events).
+func (i *InputField) ShowAutocomplete(entries []*ListItem) {
+	i.Lock()
+	i.cancelAutocomplete()
+	i.Unlock()
+
+	i.setAutocompleteEntries(context.Background(), entries)
+}
+
+// runAutocomplete invokes the autocomplete callback with the current text, or
+// with an empty text when all is true to present all entries.
+func (i *InputField) runAutocomplete(all bool) {
+	i.Lock()
+	autocomplete, autocompleteAsync := i.autocomplete, i.autocompleteAsync
+	text := string(i.text)
+	if all {
+		text = ""
+	}
+	if autocompleteAsync != nil {
+		if i.autocompleteCancel != nil {
+			i.autocompleteCancel()
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		i.autocompleteCancel = cancel
+		i.Unlock()
+
+		go autocompleteAsync(ctx, text, func(entries []*ListItem) {
+			if !i.setAutocompleteEntries(ctx, entries) {
+				return
+			}
+
+			i.RLock()
+			redraw := i.redraw
+			i.RUnlock()
+			if redraw != nil {
+				redraw()
+			}
+		})
+		return
+	}
+	i.Unlock()
+	if autocomplete == nil {
+		return
+	}
+
+	i.setAutocompleteEntries(context.Background(), autocomplete(text))
+}
+
+// cancelAutocomplete discards any pending autocomplete request. The caller must
+// hold the lock.
+func (i *InputField) cancelAutocomplete() {
+	i.autocompleteGeneration++
+	if i.autocompleteCancel != nil {
+		i.autocompleteCancel()
+		i.autocompleteCancel = nil
+	}
+}
+
+// setAutocompleteEntries updates the autocomplete list with the given entries.
+// The entries are discarded if the context was canceled. It returns whether
+// the entries were applied.
+func (i *InputField) setAutocompleteEntries(ctx context.Context, entries []*ListItem) bool {
+	i.RLock()
+	styler := i.autocompleteStyler
+	i.RUnlock()
+	if styler != nil {
+		for index, entry := range entries {
+			styler(index, entry)
+		}
 	}
 
 	i.Lock()
+	if ctx.Err() != nil {
+		i.Unlock()
+		return false
+	}
+
+	// Do we have any autocomplete entries?
+	if len(entries) == 0 {
+		// No entries, no list.
+		i.autocompleteList = nil
+		i.autocompleteListSuggestion = nil
+		i.Unlock()
+		return true
+	}
+
+	// Only show the suggestion of the first enabled entry when the list is
+	// hidden.
+	if !i.autocompleteShowList {
+		i.autocompleteList = nil
+		i.autocompleteListSuggestion = nil
+		for index, entry := range entries {
+			if entry.IsEnabled() {
+				i.autocompleteChanged(index, entry)
+				break
+			}
+		}
+		i.Unlock()
+		return true
+	}
 
 	// Make a list if we have none.
 	if i.autocompleteList == nil {
@@ -457,24 +1777,44 @@ func (i *InputField) Autocomplete() {
 		l.SetBackgroundColor(i.autocompleteListBackgroundColor)
 
 		i.autocompleteList = l
+
+		if configure := i.autocompleteConfigure; configure != nil {
+			i.Unlock()
+			configure(l)
+			i.Lock()
+			if i.autocompleteList == nil {
+				i.Unlock()
+				return true
+			}
+		}
 	}
 
 	// Fill it with the entries.
-	currentEntry := -1
+	currentEntry, firstEnabled := -1, -1
 	i.autocompleteList.Clear()
 	for index, entry := range entries {
 		i.autocompleteList.AddItem(entry)
+		if !entry.IsEnabled() {
+			continue
+		}
+		if firstEnabled < 0 {
+			firstEnabled = index
+		}
 		if currentEntry < 0 && entry.GetMainText() == string(i.text) {
 			currentEntry = index
 		}
 	}
 
-	// Set the selection if we have one.
+	// Set the selection if we have one, skipping disabled entries.
+	if currentEntry < 0 {
+		currentEntry = firstEnabled
+	}
 	if currentEntry >= 0 {
 		i.autocompleteList.SetCurrentItem(currentEntry)
 	}
 
 	i.Unlock()
+	return true
 }
 
 // autocompleteChanged gets called when another item in the
@@ -491,48 +1831,441 @@ func (i *InputField) autocompleteChanged(_ int, item *ListItem) {
 	}
 }
 
-// SetAcceptanceFunc sets a handler which may reject the last character that was
-// entered (by returning false).
-//
-// This package defines a number of variables prefixed with InputField which may
-// be used for common input (e.g. numbers, maximum text length).
-func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar rune) bool) {
+// SetAcceptanceFunc sets a handler which may reject the last character that was
+// entered (by returning false).
+//
+// This package defines a number of variables prefixed with InputField which may
+// be used for common input (e.g. numbers, maximum text length).
+func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar rune) bool) {
+	if handler == nil {
+		i.SetAcceptanceFuncEx(nil)
+		return
+	}
+	i.SetAcceptanceFuncEx(func(textToCheck string, lastChar rune) (bool, string) {
+		return handler(textToCheck, lastChar), ""
+	})
+}
+
+// SetAcceptanceFuncEx sets a handler which may reject the last character that
+// was entered (by returning false) along with a message explaining the
+// rejection, e.g. "Only digits allowed". The message is shown as the field note
+// until the next character is accepted, after which the previous field note is
+// shown again. An empty message leaves the field note unchanged. This replaces
+// any handler set via SetAcceptanceFunc.
+func (i *InputField) SetAcceptanceFuncEx(handler func(textToCheck string, lastChar rune) (bool, string)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.accept = handler
+}
+
+// SetFormatFunc sets a handler which reformats the text when the input is
+// finished by a key or the input field loses focus, e.g. to normalize a phone
+// number. The returned text replaces the text before the done and finished
+// handlers are called. The changed handler is called if the text changes.
+func (i *InputField) SetFormatFunc(handler func(text string) string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.format = handler
+}
+
+// formatText replaces the text with the text returned by the format handler.
+func (i *InputField) formatText() {
+	i.RLock()
+	format, text := i.format, string(i.text)
+	i.RUnlock()
+
+	if format == nil {
+		return
+	}
+	formatted := format(text)
+	i.setTextWithCursor(formatted, len(formatted), ChangeReasonProgrammatic)
+}
+
+// SetChangedFunc sets a handler which is called whenever the text of the input
+// field has changed. It receives the current text (after the change).
+func (i *InputField) SetChangedFunc(handler func(text string)) {
+	if handler == nil {
+		i.SetChangedFuncEx(nil)
+		return
+	}
+	i.SetChangedFuncEx(func(text string, reason ChangeReason) {
+		handler(text)
+	})
+}
+
+// SetChangedFuncEx sets a handler which is called whenever the text of the
+// input field has changed. It receives the current text (after the change) and
+// the reason for the change. This replaces any handler set via SetChangedFunc.
+func (i *InputField) SetChangedFuncEx(handler func(text string, reason ChangeReason)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.changed = handler
+}
+
+// SetDoneFunc sets a handler which is called when the user is done entering
+// text. The callback function is provided with the key that was pressed, which
+// is one of the following:
+//
+//   - KeyEnter: Done entering text.
+//   - KeyEscape: Abort text input.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+//   - KeyNUL: The input field lost focus (see SetFinishOnBlur).
+func (i *InputField) SetDoneFunc(handler func(key tcell.Key)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.done = handler
+}
+
+// SetNumericStep sets the amount by which the value of the input field is
+// incremented or decremented when pressing the Up or Down key. Stepping only
+// applies when the current text is a number and no autocomplete entries are
+// shown. Infinite values and NaN are not stepped. Results are passed to the
+// acceptance function as if they were entered by the user. A value of 0 (the
+// default) disables numeric stepping.
+func (i *InputField) SetNumericStep(step float64) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.numericStep = step
+}
+
+// SetNumericBounds sets the minimum and maximum value which may be reached by
+// numeric stepping. See SetNumericStep.
+func (i *InputField) SetNumericBounds(min, max float64) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.numericMin, i.numericMax = min, max
+	i.numericBounded = true
+}
+
+// stepNumeric increments (direction 1) or decrements (direction -1) the
+// numeric value of the input field. It returns whether or not the text was a
+// number which could be stepped. The caller must hold the lock.
+func (i *InputField) stepNumeric(direction float64) bool {
+	if i.numericStep == 0 {
+		return false
+	}
+	text := string(i.text)
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return false
+	}
+
+	value += direction * i.numericStep
+	if i.numericBounded {
+		if value < i.numericMin {
+			value = i.numericMin
+		} else if value > i.numericMax {
+			value = i.numericMax
+		}
+	}
+
+	// Round to the precision of the step or the current value, whichever is
+	// greater, to avoid floating-point artifacts.
+	decimals := func(s string) int {
+		if index := strings.IndexByte(s, '.'); index >= 0 {
+			return len(s) - index - 1
+		}
+		return 0
+	}
+	precision := decimals(strconv.FormatFloat(i.numericStep, 'f', -1, 64))
+	if p := decimals(text); p > precision {
+		precision = p
+	}
+	newText := strconv.FormatFloat(value, 'f', precision, 64)
+	if strings.IndexByte(newText, '.') >= 0 {
+		newText = strings.TrimRight(strings.TrimRight(newText, "0"), ".")
+	}
+	if newText == "-0" {
+		newText = "0"
+	}
+
+	if i.accept != nil {
+		lastChar, _ := utf8.DecodeLastRuneInString(newText)
+		if ok, _ := i.accept(newText, lastChar); !ok {
+			return true
+		}
+	}
+	i.text = []byte(newText)
+	i.cursorPos = len(i.text)
+	return true
+}
+
+// SetIgnoreEnter sets a flag which determines whether the Enter key is ignored
+// by the input field. When set, pressing Enter neither selects an autocomplete
+// entry nor invokes the done and finished handlers.
+//
+// Note that the key is swallowed rather than passed on, as key events are only
+// delivered to the focused primitive. To react to Enter, install an input
+// capture function (see Box.SetInputCapture), which is called before the key
+// is ignored.
+func (i *InputField) SetIgnoreEnter(ignore bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.ignoreEnter = ignore
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (i *InputField) SetFinishedFunc(handler func(key tcell.Key)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.finished = handler
+}
+
+// setFormChangedFunc sets a callback invoked when the value of this form item
+// changes.
+func (i *InputField) setFormChangedFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.formChanged = handler
+}
+
+// SetRedrawFunc sets a handler which is called from another goroutine when the
+// input field needs to be redrawn, e.g. when cycling placeholder texts or when
+// debounced autocomplete entries are available. The handler will typically
+// call Application.QueueUpdateDraw.
+func (i *InputField) SetRedrawFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.redraw = handler
+}
+
+// SetFocusFunc sets a handler which is called when the input field receives
+// focus, whether by keyboard navigation, mouse or a call to
+// Application.SetFocus.
+func (i *InputField) SetFocusFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.focusFunc = handler
+}
+
+// SetBlurFunc sets a handler which is called when the input field loses focus,
+// whether by keyboard navigation, mouse or a call to Application.SetFocus. It
+// is called in addition to any done or finished handler.
+func (i *InputField) SetBlurFunc(handler func()) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.blurFunc = handler
+}
+
+// SetFinishOnBlur sets a flag which determines whether the done and finished
+// handlers are called when the input field loses focus by any other means than
+// one of the keys listed in SetDoneFunc, e.g. when another primitive is
+// clicked. The handlers then receive KeyNUL. As they are called while the focus
+// is changing, they must not shift the focus themselves.
+func (i *InputField) SetFinishOnBlur(finish bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.finishOnBlur = finish
+}
+
+// SetRestrictToSuggestions sets whether the text is restricted to the entries
+// returned by the autocomplete function set via SetAutocompleteFunc, turning
+// the input field into a combo box. When the input is finished by a key or the
+// input field loses focus, text which does not exactly match the selection
+// value of an entry is handled according to the action set via
+// SetRestrictAction. An empty text is always accepted.
+//
+// The current text, text set via SetText and selected autocomplete entries
+// are considered valid.
+//
+// Entries provided via SetAutocompleteFuncAsync are not considered, because
+// they may arrive after the input was finished. The text of such input fields
+// is not restricted.
+func (i *InputField) SetRestrictToSuggestions(restrict bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.restrict = restrict
+	i.lastValidText = string(i.text)
+}
+
+// SetRestrictAction sets the action taken when the text does not match any
+// autocomplete entry while the input field is restricted to its suggestions.
+// The default is RestrictRevert.
+func (i *InputField) SetRestrictAction(action RestrictAction) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.restrictAction = action
+}
+
+// SetInvalidEntryFunc sets a handler which is called with the entered text
+// when it does not match any autocomplete entry while the input field is
+// restricted to its suggestions. The handler is called before the text is
+// replaced.
+func (i *InputField) SetInvalidEntryFunc(handler func(text string)) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.invalidEntry = handler
+}
+
+// enforceSuggestions replaces the text with the last valid text or the
+// closest autocomplete entry when the input field is restricted to its
+// suggestions and the text does not match any entry.
+func (i *InputField) enforceSuggestions() {
+	i.Lock()
+	if !i.restrict || i.autocomplete == nil || len(i.text) == 0 {
+		i.Unlock()
+		return
+	}
+	text := string(i.text)
+	autocomplete, action, invalidEntry, lastValidText := i.autocomplete, i.restrictAction, i.invalidEntry, i.lastValidText
+	i.Unlock()
+
+	entries := autocomplete(text)
+	for _, entry := range entries {
+		if autocompleteEntryText(entry) == text {
+			i.Lock()
+			i.lastValidText = text
+			i.Unlock()
+			return
+		}
+	}
+
+	if invalidEntry != nil {
+		invalidEntry(text)
+	}
+
+	replacement, reason := lastValidText, ChangeReasonProgrammatic
+	if action == RestrictSelectClosest && len(entries) > 0 {
+		replacement, reason = autocompleteEntryText(entries[0]), ChangeReasonAutocomplete
+	}
+	i.setTextWithCursor(replacement, len(replacement), reason)
+
+	i.Lock()
+	i.cancelAutocomplete() // Cancel pending autocompletion.
+	i.autocompleteList = nil
+	i.autocompleteListSuggestion = nil
+	i.Unlock()
+}
+
+// SetVimMode sets whether Vim-style modal editing is enabled. When enabled,
+// the input field starts in normal mode, where the following keys are
+// interpreted as commands:
+//
+//   - h, l: Move left or right by one character.
+//   - b, w: Move left or right by one word.
+//   - x: Delete the character under the cursor.
+//   - i: Enter insert mode.
+//   - a: Enter insert mode after the character under the cursor.
+//
+// Other keys, such as Enter and Tab, are processed as usual. In insert mode,
+// all keys are processed as usual, except for Escape, which returns to normal
+// mode.
+func (i *InputField) SetVimMode(vimMode bool) {
+	i.Lock()
+	i.vimMode = vimMode
+	mode := EditModeInsert
+	if vimMode {
+		mode = EditModeNormal
+	}
+	changed := i.editMode != mode
+	i.editMode = mode
+	editModeChanged := i.editModeChanged
+	i.Unlock()
+
+	if changed && editModeChanged != nil {
+		editModeChanged(mode)
+	}
+}
+
+// GetEditMode returns the current edit mode. When Vim-style modal editing is
+// disabled, this is always EditModeInsert.
+func (i *InputField) GetEditMode() EditMode {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.editMode
+}
+
+// SetEditModeChangedFunc sets a handler which is called when the edit mode
+// changes, e.g. to show a mode indicator.
+func (i *InputField) SetEditModeChangedFunc(handler func(mode EditMode)) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.accept = handler
+	i.editModeChanged = handler
 }
 
-// SetChangedFunc sets a handler which is called whenever the text of the input
-// field has changed. It receives the current text (after the change).
-func (i *InputField) SetChangedFunc(handler func(text string)) {
+// SetSelectAllOnFocus sets whether the entire text is selected when the input
+// field receives focus. The cursor is then placed at the end of the text and
+// the next character entered replaces the text. Any other key deselects the
+// text.
+func (i *InputField) SetSelectAllOnFocus(selectAll bool) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.changed = handler
+	i.selectAllOnFocus = selectAll
 }
 
-// SetDoneFunc sets a handler which is called when the user is done entering
-// text. The callback function is provided with the key that was pressed, which
-// is one of the following:
-//
-//   - KeyEnter: Done entering text.
-//   - KeyEscape: Abort text input.
-//   - KeyTab: Move to the next field.
-//   - KeyBacktab: Move to the previous field.
-func (i *InputField) SetDoneFunc(handler func(key tcell.Key)) {
+// Focus is called when this primitive receives focus.
+func (i *InputField) Focus(delegate func(p Primitive)) {
+	hadFocus := i.Box.HasFocus()
+
 	i.Lock()
-	defer i.Unlock()
+	i.stopPlaceholderRotation()
+	if !hadFocus && i.selectAllOnFocus && len(i.text) > 0 {
+		i.selectedAll = true
+		i.cursorPos = len(i.text)
+	}
+	focusFunc := i.focusFunc
+	i.Unlock()
 
-	i.done = handler
+	i.Box.Focus(delegate)
+
+	if !hadFocus && focusFunc != nil {
+		focusFunc()
+	}
 }
 
-// SetFinishedFunc sets a callback invoked when the user leaves this form item.
-func (i *InputField) SetFinishedFunc(handler func(key tcell.Key)) {
+// Blur is called when this primitive loses focus.
+func (i *InputField) Blur() {
+	hadFocus := i.Box.HasFocus()
+
+	i.Box.Blur()
+
+	i.RLock()
+	finishedByKey := i.finishedByKey
+	i.RUnlock()
+	if hadFocus && !finishedByKey {
+		i.enforceSuggestions()
+		i.formatText()
+	}
+
 	i.Lock()
-	defer i.Unlock()
+	i.selectedAll = false
+	blurFunc := i.blurFunc
+	var done, finished func(tcell.Key)
+	if hadFocus && i.finishOnBlur && !i.finishedByKey {
+		done, finished = i.done, i.finished
+	}
+	i.finishedByKey = false
+	i.Unlock()
 
-	i.finished = handler
+	if done != nil {
+		done(tcell.KeyNUL)
+	}
+	if finished != nil {
+		finished(tcell.KeyNUL)
+	}
+	if hadFocus && blurFunc != nil {
+		blurFunc()
+	}
 }
 
 // Draw draws this primitive onto the screen.
@@ -561,24 +2294,32 @@ func (i *InputField) Draw(screen tcell.Screen) {
 			fieldTextColor = i.fieldTextColorFocused
 		}
 	}
+	if i.errorState {
+		fieldBackgroundColor = i.errorColor
+	}
 
 	// Prepare
 	x, y, width, height := i.GetInnerRect()
 	rightLimit := x + width
+	bottomLimit := y + height
 	if height < 1 || rightLimit <= x {
 		return
 	}
 
 	// Draw label.
-	if i.labelWidth > 0 {
+	label := suffixedLabel(i.label, i.labelSuffix)
+	if i.labelPosition == LabelAbove {
+		Print(screen, label, x, y, width, AlignLeft, labelColor)
+		y++
+	} else if i.labelWidth > 0 {
 		labelWidth := i.labelWidth
 		if labelWidth > rightLimit-x {
 			labelWidth = rightLimit - x
 		}
-		Print(screen, i.label, x, y, labelWidth, AlignLeft, labelColor)
+		Print(screen, label, x, y, labelWidth, AlignLeft, labelColor)
 		x += labelWidth
 	} else {
-		_, drawnWidth := Print(screen, i.label, x, y, rightLimit-x, AlignLeft, labelColor)
+		_, drawnWidth := Print(screen, label, x, y, rightLimit-x, AlignLeft, labelColor)
 		x += drawnWidth
 	}
 
@@ -591,32 +2332,76 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	if rightLimit-x < fieldWidth {
 		fieldWidth = rightLimit - x
 	}
+	i.fieldY, i.fieldScreenWidth = y, fieldWidth
 	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor)
 	for index := 0; index < fieldWidth; index++ {
 		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
 	}
 
+	// Clear button. Its cell is excluded from the area the text is drawn in.
+	i.clearButtonX = -1
+	clearButton := i.showClearButton && len(i.text) > 0 && fieldWidth > 1
+	if clearButton {
+		i.clearButtonX = x + fieldWidth - 1
+		screen.SetContent(i.clearButtonX, y, i.clearButtonRune, nil, fieldStyle.Foreground(fieldTextColor))
+		fieldWidth--
+	}
+
 	// Text.
 	var cursorScreenPos int
+	var cursorHidden bool
+	i.textX = x
 	text := i.text
-	if len(text) == 0 && len(i.placeholder) > 0 {
+	placeholder := i.placeholder
+	if len(i.placeholderRotation) > 0 && !i.GetFocusable().HasFocus() {
+		placeholder = i.placeholderRotation[i.placeholderRotationIndex]
+	} else if i.hidePlaceholderOnFocus && i.GetFocusable().HasFocus() {
+		placeholder = nil
+	}
+	if len(text) == 0 && len(placeholder) > 0 {
 		// Draw placeholder text.
 		placeholderTextColor := i.placeholderTextColor
 		if i.GetFocusable().HasFocus() && i.placeholderTextColorFocused != ColorUnset {
 			placeholderTextColor = i.placeholderTextColorFocused
 		}
-		Print(screen, EscapeBytes(i.placeholder), x, y, fieldWidth, AlignLeft, placeholderTextColor)
+		Print(screen, placeholder, x, y, fieldWidth, i.fieldAlign, placeholderTextColor)
 		i.offset = 0
+
+		// Cycle rotating placeholder texts while they are drawn.
+		i.placeholderRotationDrawn = true
+		i.startPlaceholderRotation()
 	} else {
 		// Draw entered text.
 		if i.maskCharacter > 0 {
-			text = bytes.Repeat([]byte(string(i.maskCharacter)), utf8.RuneCount(i.text))
+			if i.maskRevealLen > 0 && i.maskRevealPos+i.maskRevealLen <= len(i.text) {
+				mask := []byte(string(i.maskCharacter))
+				revealEnd := i.maskRevealPos + i.maskRevealLen
+				text = bytes.Repeat(mask, utf8.RuneCount(i.text[:i.maskRevealPos]))
+				text = append(text, i.text[i.maskRevealPos:revealEnd]...)
+				text = append(text, bytes.Repeat(mask, utf8.RuneCount(i.text[revealEnd:]))...)
+			} else {
+				text = bytes.Repeat([]byte(string(i.maskCharacter)), utf8.RuneCount(i.text))
+			}
+		}
+		textStyle := tcell.StyleDefault.Foreground(fieldTextColor)
+		if i.selectedAll {
+			textStyle = tcell.StyleDefault.Foreground(fieldBackgroundColor).Background(fieldTextColor)
 		}
 		var drawnText []byte
-		if fieldWidth > runewidth.StringWidth(string(text)) {
-			// We have enough space for the full text.
+		if textWidth := runewidth.StringWidth(string(text)); fieldWidth > textWidth {
+			// We have enough space for the full text. Leave room for the cursor
+			// after the text when aligning.
+			var alignOffset int
+			if i.fieldAlign == AlignCenter {
+				alignOffset = (fieldWidth - textWidth - 1) / 2
+			} else if i.fieldAlign == AlignRight {
+				alignOffset = fieldWidth - textWidth - 1
+			}
+			cursorScreenPos = alignOffset
+			i.textX = x + alignOffset
+
 			drawnText = EscapeBytes(text)
-			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
+			PrintStyle(screen, drawnText, i.textX, y, fieldWidth-alignOffset, AlignLeft, textStyle)
 			i.offset = 0
 			iterateString(string(text), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
 				if textPos >= i.cursorPos {
@@ -626,18 +2411,37 @@ func (i *InputField) Draw(screen tcell.Screen) {
 				return false
 			})
 		} else {
-			// The text doesn't fit. Where is the cursor?
+			// The text doesn't fit. Reserve the edges for the overflow
+			// indicators.
+			textAreaX, textAreaWidth := x, fieldWidth
+			if i.overflowIndicator != 0 && fieldWidth > 2 {
+				textAreaX, textAreaWidth = x+1, fieldWidth-2
+			}
+			cursorScreenPos = textAreaX - x
+			i.textX = textAreaX
+
+			// Where is the cursor?
 			if i.cursorPos < 0 {
 				i.cursorPos = 0
 			} else if i.cursorPos > len(text) {
 				i.cursorPos = len(text)
 			}
-			// Shift the text so the cursor is inside the field.
+			// Shift the text so the cursor is inside the field, unless the
+			// text was scrolled via ScrollTo and the cursor has not moved
+			// since.
+			keepOffset := i.scrolled && i.scrollCursorPos == i.cursorPos
+			if !keepOffset {
+				i.scrolled = false
+			} else if i.offset > len(text) {
+				i.offset = len(text)
+			}
 			var shiftLeft int
-			if i.offset > i.cursorPos {
+			if keepOffset {
+				cursorHidden = i.cursorPos < i.offset
+			} else if i.offset > i.cursorPos {
 				i.offset = i.cursorPos
-			} else if subWidth := runewidth.StringWidth(string(text[i.offset:i.cursorPos])); subWidth > fieldWidth-1 {
-				shiftLeft = subWidth - fieldWidth + 1
+			} else if subWidth := runewidth.StringWidth(string(text[i.offset:i.cursorPos])); subWidth > textAreaWidth-1 {
+				shiftLeft = subWidth - textAreaWidth + 1
 			}
 			currentOffset := i.offset
 			iterateString(string(text), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
@@ -650,22 +2454,73 @@ func (i *InputField) Draw(screen tcell.Screen) {
 							return true
 						}
 						cursorScreenPos += screenWidth
+						if keepOffset && cursorScreenPos >= textAreaX-x+textAreaWidth {
+							cursorHidden = true
+							return true
+						}
 					}
 				}
 				return false
 			})
 			drawnText = EscapeBytes(text[i.offset:])
-			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
+			PrintStyle(screen, drawnText, textAreaX, y, textAreaWidth, AlignLeft, textStyle)
+
+			// Draw overflow indicators.
+			if textAreaX > x {
+				indicatorStyle := fieldStyle.Foreground(fieldTextColor)
+				if i.offset > 0 {
+					screen.SetContent(x, y, i.overflowIndicator, nil, indicatorStyle)
+				}
+				if runewidth.StringWidth(string(text[i.offset:])) > textAreaWidth {
+					screen.SetContent(x+fieldWidth-1, y, i.overflowIndicator, nil, indicatorStyle)
+				}
+			}
 		}
 		// Draw suggestion
-		if i.maskCharacter == 0 && len(i.autocompleteListSuggestion) > 0 {
-			Print(screen, i.autocompleteListSuggestion, x+runewidth.StringWidth(string(drawnText)), y, fieldWidth-runewidth.StringWidth(string(drawnText)), AlignLeft, i.autocompleteSuggestionTextColor)
+		if i.showSuggestion() {
+			suggestion := i.autocompleteListSuggestion
+			if i.maskCharacter > 0 {
+				suggestion = bytes.Repeat([]byte(string(i.maskCharacter)), utf8.RuneCount(suggestion))
+			}
+			suggestionOffset := i.textX - x + runewidth.StringWidth(string(drawnText))
+			Print(screen, suggestion, x+suggestionOffset, y, fieldWidth-suggestionOffset, AlignLeft, i.autocompleteSuggestionTextColor)
+		}
+	}
+
+	if clearButton {
+		fieldWidth++
+	}
+
+	// Draw strength meter.
+	noteY := y + 1
+	if i.showStrength() && noteY < bottomLimit {
+		score, label := i.strengthFunc(string(i.text))
+		if score < 0 {
+			score = 0
+		} else if score >= len(i.strengthColors) {
+			score = len(i.strengthColors) - 1
+		}
+		color := i.strengthColors[score]
+		for index := 0; index < len(i.strengthColors) && index < fieldWidth; index++ {
+			if index <= score {
+				screen.SetContent(x+index, noteY, i.strengthRune, nil, tcell.StyleDefault.Foreground(color))
+			} else {
+				screen.SetContent(x+index, noteY, i.strengthEmptyRune, nil, tcell.StyleDefault.Foreground(i.strengthEmptyColor))
+			}
 		}
+		labelX := len(i.strengthColors) + 1
+		Print(screen, []byte(label), x+labelX, noteY, fieldWidth-labelX, AlignLeft, color)
+		noteY++
 	}
 
 	// Draw field note
 	if len(i.fieldNote) > 0 {
-		Print(screen, i.fieldNote, x, y+1, fieldWidth, AlignLeft, i.fieldNoteTextColor)
+		for index, line := range i.noteLines(fieldWidth) {
+			if noteY+index >= bottomLimit {
+				break
+			}
+			Print(screen, line, x, noteY+index, fieldWidth, AlignLeft, i.fieldNoteTextColor)
+		}
 	}
 
 	// Draw autocomplete list.
@@ -680,6 +2535,9 @@ func (i *InputField) Draw(screen tcell.Screen) {
 				lwidth = width
 			}
 		}
+		if i.autocompleteMaxHeight > 0 && lheight > i.autocompleteMaxHeight {
+			lheight = i.autocompleteMaxHeight
+		}
 
 		// We prefer to drop down but if there is no space, maybe drop up?
 		lx := x
@@ -694,16 +2552,40 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		if ly+lheight >= sheight {
 			lheight = sheight - ly
 		}
+
+		// Reserve the last row for the number of hidden entries.
+		var overflow []byte
+		if count := i.autocompleteList.GetItemCount(); i.autocompleteShowOverflowCount && count > lheight && lheight > 1 {
+			lheight--
+			overflow = []byte("… and " + strconv.Itoa(count-lheight) + " more")
+			if width := runewidth.StringWidth(string(overflow)); width > lwidth {
+				lwidth = width
+			}
+		}
 		if i.autocompleteList.scrollBarVisibility == ScrollBarAlways || (i.autocompleteList.scrollBarVisibility == ScrollBarAuto && i.autocompleteList.GetItemCount() > lheight) {
 			lwidth++ // Add space for scroll bar
 		}
 		i.autocompleteList.SetRect(lx, ly, lwidth, lheight)
 		i.autocompleteList.Draw(screen)
+
+		if overflow != nil {
+			overflowStyle := tcell.StyleDefault.Background(i.autocompleteListBackgroundColor)
+			for index := 0; index < lwidth; index++ {
+				screen.SetContent(lx+index, ly+lheight, ' ', nil, overflowStyle)
+			}
+			Print(screen, overflow, lx, ly+lheight, lwidth, AlignLeft, i.autocompleteOverflowTextColor)
+		}
 	}
 
 	// Set cursor.
-	if i.focus.HasFocus() {
-		screen.ShowCursor(x+cursorScreenPos, y)
+	i.cursorScreenX, i.cursorScreenY = x+cursorScreenPos, y
+	if i.focus.HasFocus() && !cursorHidden {
+		cursorStyle := i.Box.GetCursorStyle()
+		if i.overwrite {
+			cursorStyle = i.overwriteCursorStyle
+		}
+		screen.SetCursorStyle(cursorStyle)
+		screen.ShowCursor(i.cursorScreenX, i.cursorScreenY)
 	}
 }
 
@@ -712,17 +2594,39 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		i.Lock()
 
+		// Mask any revealed character. It is revealed again below if the key
+		// adds a character.
+		i.maskRevealLen = 0
+		i.finishedByKey = false
+
+		// Only a key directly following a yank may cycle the yanked text.
+		yanked := i.yanked
+		i.yanked = false
+
+		// Any key deselects the text. It is replaced below if the key adds a
+		// character.
+		selectedAll := i.selectedAll
+		i.selectedAll = false
+
 		// Trigger changed events.
 		currentText := i.text
+		reason := ChangeReasonUserInput
 		defer func() {
 			i.Lock()
 			newText := i.text
+			if i.clearErrorOnChange && !bytes.Equal(newText, currentText) {
+				i.errorState = false
+			}
+			changed, formChanged := i.changed, i.formChanged
 			i.Unlock()
 
 			if !bytes.Equal(newText, currentText) {
-				i.Autocomplete()
-				if i.changed != nil {
-					i.changed(string(i.text))
+				i.autocompleteInput()
+				if changed != nil {
+					changed(string(newText), reason)
+				}
+				if formChanged != nil {
+					formChanged()
 				}
 			}
 		}()
@@ -743,176 +2647,455 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 			})
 		}
 		moveWordLeft := func() {
-			i.cursorPos = len(regexRightWord.ReplaceAll(i.text[:i.cursorPos], nil))
+			i.cursorPos = PrevWordBoundary(string(i.text), i.cursorPos)
+		}
+		clusterBefore := func(pos int) (width int) {
+			iterateStringReverse(string(i.text[:pos]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				width = textWidth
+				return true
+			})
+			return
+		}
+		yank := func(index int) {
+			if len(i.killRing) == 0 {
+				return
+			}
+			reason = ChangeReasonPaste
+			i.yankIndex = index % len(i.killRing)
+			i.yankStart = i.cursorPos
+			i.insertText(string(i.killRing[len(i.killRing)-1-i.yankIndex]))
+			i.yankEnd = i.cursorPos
+			i.yanked = true
+		}
+		changeWordCase := func(transform func(word string) string) {
+			// Transform the text from the cursor to the end of the next word.
+			end := i.cursorPos
+			for end < len(i.text) {
+				r, _ := utf8.DecodeRune(i.text[end:])
+				if isWordRune(r) {
+					break
+				}
+				end = NextWordBoundary(string(i.text), end)
+			}
+			end = NextWordBoundary(string(i.text), end)
+			word := transform(string(i.text[i.cursorPos:end]))
+			text := append(i.text[:i.cursorPos:i.cursorPos], word...)
+			i.text = append(text, i.text[end:]...)
+			i.cursorPos += len(word)
+		}
+		transpose := func() {
+			// Swap the characters before and at the cursor, or the last two
+			// characters when the cursor is at the end of the text.
+			mid := i.cursorPos
+			if mid == len(i.text) {
+				mid -= clusterBefore(mid)
+			}
+			var end int
+			iterateString(string(i.text[mid:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				end = mid + textWidth
+				return true
+			})
+			start := mid - clusterBefore(mid)
+			if start == mid || end == mid {
+				return
+			}
+			text := make([]byte, 0, len(i.text))
+			text = append(text, i.text[:start]...)
+			text = append(text, i.text[mid:end]...)
+			text = append(text, i.text[start:mid]...)
+			i.text = append(text, i.text[end:]...)
+			i.cursorPos = end
 		}
 		moveWordRight := func() {
-			i.cursorPos = len(i.text) - len(regexLeftWord.ReplaceAll(i.text[i.cursorPos:], nil))
+			i.cursorPos = NextWordBoundary(string(i.text), i.cursorPos)
 		}
 
 		// Add character function. Returns whether or not the rune character is
 		// accepted.
 		add := func(r rune) bool {
-			newText := append(append(i.text[:i.cursorPos], []byte(string(r))...), i.text[i.cursorPos:]...)
-			if i.accept != nil && !i.accept(string(newText), r) {
-				return false
+			// In overwrite mode, replace the character under the cursor.
+			replaceEnd := i.cursorPos
+			if i.overwrite {
+				iterateString(string(i.text[i.cursorPos:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+					replaceEnd += textWidth
+					return true
+				})
+			}
+			newText := append(append(i.text[:i.cursorPos:i.cursorPos], []byte(string(r))...), i.text[replaceEnd:]...)
+			if i.accept != nil {
+				ok, message := i.accept(string(newText), r)
+				if !ok {
+					if message != "" {
+						if !i.rejectionNote {
+							i.savedFieldNote = i.fieldNote
+						}
+						i.fieldNote = []byte(message)
+						i.rejectionNote = true
+					}
+					return false
+				}
+			}
+			if i.rejectionNote {
+				i.fieldNote = i.savedFieldNote
+				i.rejectionNote = false
+				i.savedFieldNote = nil
 			}
 			i.text = newText
+			i.revealMasked(i.cursorPos, len(string(r)))
 			i.cursorPos += len(string(r))
 			return true
 		}
 
+		// Commit the selected autocomplete entry.
+		commit := func() {
+			i.commitAutocomplete()
+
+			i.Lock()
+			currentText = i.text // The changed handlers were already called.
+			i.Unlock()
+		}
+
 		// Finish up.
 		finish := func(key tcell.Key) {
-			if i.done != nil {
-				i.done(key)
+			i.enforceSuggestions()
+			i.formatText()
+
+			i.Lock()
+			currentText = i.text // The changed handlers were already called.
+			i.finishedByKey = true
+			done, finished := i.done, i.finished
+			i.Unlock()
+
+			if done != nil {
+				done(key)
 			}
-			if i.finished != nil {
-				i.finished(key)
+			if finished != nil {
+				finished(key)
 			}
 		}
 
-		// Process key event.
-		switch key := event.Key(); key {
-		case tcell.KeyRune: // Regular character.
-			if event.Modifiers()&tcell.ModAlt > 0 {
-				// We accept some Alt- key combinations.
+		// Process Vim-style modal editing.
+		if i.vimMode {
+			mode, handled := i.editMode, true
+			if mode == EditModeInsert && event.Key() == tcell.KeyEscape {
+				i.cancelAutocomplete() // Cancel pending autocompletion.
+				i.autocompleteList = nil
+				i.autocompleteListSuggestion = nil
+				mode = EditModeNormal
+			} else if mode == EditModeNormal && event.Key() == tcell.KeyRune {
 				switch event.Rune() {
-				case 'a': // Home.
-					home()
-				case 'e': // End.
-					end()
-				case 'b': // Move word left.
+				case 'h':
+					moveLeft()
+				case 'l':
+					moveRight()
+				case 'b':
 					moveWordLeft()
-				case 'f': // Move word right.
+				case 'w':
 					moveWordRight()
-				default:
-					if !add(event.Rune()) {
-						i.Unlock()
-						return
-					}
+				case 'x':
+					reason = ChangeReasonDelete
+					iterateString(string(i.text[i.cursorPos:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+						i.text = append(i.text[:i.cursorPos:i.cursorPos], i.text[i.cursorPos+textWidth:]...)
+						return true
+					})
+				case 'i':
+					mode = EditModeInsert
+				case 'a':
+					moveRight()
+					mode = EditModeInsert
 				}
 			} else {
-				// Other keys are simply accepted as regular characters.
-				if !add(event.Rune()) {
-					i.Unlock()
-					return
+				handled = false
+			}
+
+			if handled {
+				changed := mode != i.editMode
+				i.editMode = mode
+				editModeChanged := i.editModeChanged
+				i.Unlock()
+
+				if changed && editModeChanged != nil {
+					editModeChanged(mode)
 				}
+				return
 			}
-		case tcell.KeyCtrlU: // Delete all.
+		}
+
+		// Accept the suggested completion.
+		if key := event.Key(); key != tcell.KeyNUL && key == i.acceptSuggestionKey && event.Modifiers()&tcell.ModAlt == 0 &&
+			i.cursorPos == len(i.text) && i.showSuggestion() {
+			reason = ChangeReasonAutocomplete
+			i.text = append(i.text[:len(i.text):len(i.text)], i.autocompleteListSuggestion...)
+			i.cursorPos = len(i.text)
+			i.autocompleteListSuggestion = nil
+			i.Unlock()
+			return
+		}
+
+		// Commit the selected autocomplete entry.
+		if key := event.Key(); key != tcell.KeyNUL && key == i.autocompleteCommitKey && i.autocompleteList != nil {
+			i.Unlock()
+			commit()
+			return
+		}
+
+		// Process key event.
+		key := event.Key()
+		switch {
+		case key == tcell.KeyHome && i.autocompleteList != nil: // Autocomplete selection.
+			i.autocompleteList.Transform(TransformFirstItem)
+		case key == tcell.KeyEnd && i.autocompleteList != nil: // Autocomplete selection.
+			i.autocompleteList.Transform(TransformLastItem)
+		case HitShortcut(event, Keys.MoveLineStart):
+			home()
+		case HitShortcut(event, Keys.MoveLineEnd):
+			end()
+		case HitShortcut(event, Keys.MoveWordLeft):
+			moveWordLeft()
+		case HitShortcut(event, Keys.MoveWordRight):
+			moveWordRight()
+		case HitShortcut(event, Keys.DeleteAll):
+			reason = ChangeReasonDelete
+			i.kill(i.text)
 			i.text = nil
 			i.cursorPos = 0
-		case tcell.KeyCtrlK: // Delete until the end of the line.
+		case HitShortcut(event, Keys.DeleteToEnd):
+			reason = ChangeReasonDelete
+			i.kill(i.text[i.cursorPos:])
 			i.text = i.text[:i.cursorPos]
-		case tcell.KeyCtrlW: // Delete last word.
-			newText := append(regexRightWord.ReplaceAll(i.text[:i.cursorPos], nil), i.text[i.cursorPos:]...)
-			i.cursorPos -= len(i.text) - len(newText)
-			i.text = newText
-		case tcell.KeyBackspace, tcell.KeyBackspace2: // Delete character before the cursor.
-			iterateStringReverse(string(i.text[:i.cursorPos]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
-				i.text = append(i.text[:textPos], i.text[textPos+textWidth:]...)
-				i.cursorPos -= textWidth
-				return true
-			})
-			if i.offset >= i.cursorPos {
-				i.offset = 0
-			}
-		case tcell.KeyDelete: // Delete character after the cursor.
-			iterateString(string(i.text[i.cursorPos:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
-				i.text = append(i.text[:i.cursorPos], i.text[i.cursorPos+textWidth:]...)
-				return true
-			})
-		case tcell.KeyLeft:
-			if event.Modifiers()&tcell.ModAlt > 0 {
-				moveWordLeft()
-			} else {
+		case HitShortcut(event, Keys.TransposeCharacters):
+			transpose()
+		case HitShortcut(event, Keys.UppercaseWord):
+			changeWordCase(strings.ToUpper)
+		case HitShortcut(event, Keys.LowercaseWord):
+			changeWordCase(strings.ToLower)
+		case HitShortcut(event, Keys.CapitalizeWord):
+			changeWordCase(capitalize)
+		case HitShortcut(event, Keys.ShowAutocomplete):
+			i.Unlock()
+			i.runAutocomplete(true)
+			return
+		case HitShortcut(event, Keys.ToggleOverwrite):
+			i.overwrite = !i.overwrite
+		case HitShortcut(event, Keys.Yank):
+			yank(0)
+		case yanked && HitShortcut(event, Keys.YankPop): // Otherwise processed like any other key.
+			i.text = append(i.text[:i.yankStart:i.yankStart], i.text[i.yankEnd:]...)
+			i.cursorPos = i.yankStart
+			yank(i.yankIndex + 1)
+		case HitShortcut(event, Keys.DeleteWord):
+			reason = ChangeReasonDelete
+			wordStart := PrevWordBoundary(string(i.text), i.cursorPos)
+			i.kill(i.text[wordStart:i.cursorPos])
+			i.text = append(i.text[:wordStart:wordStart], i.text[i.cursorPos:]...)
+			i.cursorPos = wordStart
+		default:
+			switch key {
+			case tcell.KeyRune: // Regular character.
+				if selectedAll {
+					i.text, i.cursorPos = nil, 0
+				}
+				if !add(event.Rune()) {
+					if selectedAll {
+						i.text, i.cursorPos, i.selectedAll = currentText, len(currentText), true
+					}
+					i.Unlock()
+					return
+				}
+			case tcell.KeyBackspace, tcell.KeyBackspace2: // Delete character before the cursor.
+				reason = ChangeReasonDelete
+				if selectedAll {
+					i.text, i.cursorPos = nil, 0
+					break
+				}
+				iterateStringReverse(string(i.text[:i.cursorPos]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+					i.text = append(i.text[:textPos], i.text[textPos+textWidth:]...)
+					i.cursorPos -= textWidth
+					return true
+				})
+				if i.offset >= i.cursorPos {
+					i.offset = 0
+				}
+			case tcell.KeyDelete: // Delete character after the cursor.
+				reason = ChangeReasonDelete
+				if selectedAll {
+					i.text, i.cursorPos = nil, 0
+					break
+				}
+				iterateString(string(i.text[i.cursorPos:]), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+					i.text = append(i.text[:i.cursorPos], i.text[i.cursorPos+textWidth:]...)
+					return true
+				})
+			case tcell.KeyLeft:
 				moveLeft()
-			}
-		case tcell.KeyRight:
-			if event.Modifiers()&tcell.ModAlt > 0 {
-				moveWordRight()
-			} else {
+			case tcell.KeyRight:
 				moveRight()
-			}
-		case tcell.KeyHome, tcell.KeyCtrlA:
-			home()
-		case tcell.KeyEnd, tcell.KeyCtrlE:
-			end()
-		case tcell.KeyEnter: // We might be done.
-			if i.autocompleteList != nil {
-				currentItem := i.autocompleteList.GetCurrentItem()
-				selectionText := currentItem.GetMainText()
-				if currentItem.GetSecondaryText() != "" {
-					selectionText = currentItem.GetSecondaryText()
+			case tcell.KeyPgUp: // Autocomplete selection.
+				if i.autocompleteList != nil {
+					i.autocompleteList.Transform(TransformPreviousPage)
 				}
-				i.Unlock()
-				i.SetText(selectionText)
-				i.Lock()
-				i.autocompleteList = nil
-				i.autocompleteListSuggestion = nil
-				i.Unlock()
-			} else {
-				i.Unlock()
-				finish(key)
-			}
-			return
-		case tcell.KeyEscape:
-			if i.autocompleteList != nil {
-				i.autocompleteList = nil
-				i.autocompleteListSuggestion = nil
-				i.Unlock()
-			} else {
-				i.Unlock()
-				finish(key)
-			}
-			return
-		case tcell.KeyDown, tcell.KeyTab: // Autocomplete selection.
-			if i.autocompleteList != nil {
-				count := i.autocompleteList.GetItemCount()
-				newEntry := i.autocompleteList.GetCurrentItemIndex() + 1
-				if newEntry >= count {
-					newEntry = 0
+			case tcell.KeyPgDn: // Autocomplete selection.
+				if i.autocompleteList != nil {
+					i.autocompleteList.Transform(TransformNextPage)
 				}
-				i.autocompleteList.SetCurrentItem(newEntry)
-				i.Unlock()
-			} else {
-				i.Unlock()
-				finish(key)
-			}
-			return
-		case tcell.KeyUp, tcell.KeyBacktab: // Autocomplete selection.
-			if i.autocompleteList != nil {
-				newEntry := i.autocompleteList.GetCurrentItemIndex() - 1
-				if newEntry < 0 {
-					newEntry = i.autocompleteList.GetItemCount() - 1
+			case tcell.KeyEnter: // We might be done.
+				if i.ignoreEnter {
+					i.Unlock()
+					return
 				}
-				i.autocompleteList.SetCurrentItem(newEntry)
-				i.Unlock()
-			} else {
-				i.Unlock()
-				finish(key)
+				if i.autocompleteList != nil {
+					i.Unlock()
+					commit()
+				} else {
+					i.Unlock()
+					finish(key)
+				}
+				return
+			case tcell.KeyEscape:
+				i.cancelAutocomplete() // Cancel pending autocompletion.
+				if i.autocompleteList != nil {
+					i.autocompleteList = nil
+					i.autocompleteListSuggestion = nil
+					i.Unlock()
+				} else {
+					i.Unlock()
+					finish(key)
+				}
+				return
+			case tcell.KeyDown, tcell.KeyTab: // Autocomplete selection.
+				if key == tcell.KeyDown && i.autocompleteList == nil && i.stepNumeric(-1) {
+					break
+				}
+				if i.autocompleteList != nil {
+					i.selectAutocompleteEntry(1)
+					i.Unlock()
+				} else {
+					i.Unlock()
+					finish(key)
+				}
+				return
+			case tcell.KeyUp, tcell.KeyBacktab: // Autocomplete selection.
+				if key == tcell.KeyUp && i.autocompleteList == nil && i.stepNumeric(1) {
+					break
+				}
+				if i.autocompleteList != nil {
+					i.selectAutocompleteEntry(-1)
+					i.Unlock()
+				} else {
+					i.Unlock()
+					finish(key)
+				}
+				return
 			}
-			return
 		}
 
 		i.Unlock()
 	})
 }
 
+// selectAutocompleteEntry selects the next (step 1) or previous (step -1)
+// enabled entry of the autocomplete list, wrapping around. The caller must
+// hold the lock.
+func (i *InputField) selectAutocompleteEntry(step int) {
+	count := i.autocompleteList.GetItemCount()
+	index := i.autocompleteList.GetCurrentItemIndex()
+	for n := 0; n < count; n++ {
+		index = (index + step + count) % count
+		if i.autocompleteList.GetItem(index).IsEnabled() {
+			i.autocompleteList.SetCurrentItem(index)
+			return
+		}
+	}
+}
+
+// commitAutocomplete replaces the text with the selected autocomplete entry
+// and hides the autocomplete list. Disabled entries are not accepted.
+func (i *InputField) commitAutocomplete() {
+	i.Lock()
+	if i.autocompleteList == nil {
+		i.Unlock()
+		return
+	}
+	currentItem := i.autocompleteList.GetCurrentItem()
+	i.Unlock()
+
+	if currentItem == nil || !currentItem.IsEnabled() {
+		return
+	}
+
+	selectionText := autocompleteEntryText(currentItem)
+	i.setTextWithCursor(selectionText, len(selectionText), ChangeReasonAutocomplete)
+
+	i.Lock()
+	i.autocompleteList = nil
+	i.autocompleteListSuggestion = nil
+	i.Unlock()
+}
+
+// autocompleteEntryText returns the selection value of an autocomplete entry.
+func autocompleteEntryText(entry *ListItem) string {
+	if entry.GetSecondaryText() != "" {
+		return entry.GetSecondaryText()
+	}
+	return entry.GetMainText()
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return i.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 		x, y := event.Position()
+
+		// Scroll the autocomplete list, or select and commit an entry.
+		i.RLock()
+		autocompleteList := i.autocompleteList
+		i.RUnlock()
+		if autocompleteList != nil && autocompleteList.InRect(x, y) {
+			switch action {
+			case MouseScrollUp, MouseScrollDown:
+				autocompleteList.MouseHandler()(action, event, func(p Primitive) {})
+			case MouseLeftClick:
+				autocompleteList.RLock()
+				index := autocompleteList.indexAtPoint(x, y)
+				autocompleteList.RUnlock()
+				autocompleteList.MouseHandler()(action, event, func(p Primitive) {})
+				if index >= 0 && autocompleteList.GetCurrentItemIndex() == index {
+					i.commitAutocomplete()
+				}
+			}
+			return true, nil
+		}
+
 		_, rectY, _, _ := i.GetInnerRect()
 		if !i.InRect(x, y) {
 			return false, nil
 		}
+		if i.GetLabelPosition() == LabelAbove {
+			rectY++
+		}
+
+		// Clear the text.
+		i.RLock()
+		clearButtonX := i.clearButtonX
+		i.RUnlock()
+		if action == MouseLeftClick && y == rectY && x == clearButtonX {
+			i.setTextWithCursor("", 0, ChangeReasonDelete)
+			setFocus(i)
+			return true, nil
+		}
 
 		// Process mouse event.
 		if action == MouseLeftClick && y == rectY {
+			// Clicking a focused field deselects the text.
+			if i.GetFocusable().HasFocus() {
+				i.Lock()
+				i.selectedAll = false
+				i.Unlock()
+			}
+
 			// Determine where to place the cursor.
 			if x >= i.fieldX {
 				if !iterateString(string(i.text), func(main rune, comb []rune, textPos int, textWidth int, screenPos int, screenWidth int) bool {
-					if x-i.fieldX < screenPos+screenWidth {
+					if x-i.textX < screenPos+screenWidth {
 						i.cursorPos = textPos
 						return true
 					}
@@ -923,13 +3106,21 @@ func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventM
 			}
 			setFocus(i)
 			consumed = true
+		} else if action == MouseScrollLeft || action == MouseScrollRight {
+			i.Lock()
+			offset := i.offset
+			if action == MouseScrollLeft && offset > 0 {
+				_, size := utf8.DecodeLastRune(i.text[:offset])
+				offset -= size
+			} else if action == MouseScrollRight && offset < len(i.text) {
+				_, size := utf8.DecodeRune(i.text[offset:])
+				offset += size
+			}
+			i.Unlock()
+			i.ScrollTo(offset)
+			consumed = true
 		}
 
 		return
 	})
 }
-
-var (
-	regexRightWord = regexp.MustCompile(`(\w*|\W)$`)
-	regexLeftWord  = regexp.MustCompile(`^(\W|\w*)`)
-)
@@ -0,0 +1,2119 @@
+package cview
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	testInputFieldFamily = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // ZWJ sequence
+	testInputFieldFlag   = "\U0001F1E9\U0001F1EA"                       // Regional indicators
+	testInputFieldAcute  = "e\u0301"                                    // Combining mark
+)
+
+func sendInputFieldKey(i *InputField, key tcell.Key, ch rune, mod tcell.ModMask) {
+	i.InputHandler()(tcell.NewEventKey(key, ch, mod), func(p Primitive) {})
+}
+
+func TestInputFieldGraphemeClusters(t *testing.T) {
+	t.Parallel()
+
+	for _, cluster := range []string{testInputFieldFamily, testInputFieldFlag, testInputFieldAcute} {
+		text := "a" + cluster + "b"
+
+		i := NewInputField()
+		i.SetText(text)
+
+		// Move left over "b" and then over the cluster.
+
+		sendInputFieldKey(i, tcell.KeyLeft, 0, tcell.ModNone)
+		sendInputFieldKey(i, tcell.KeyLeft, 0, tcell.ModNone)
+		if i.GetCursorPosition() != 1 {
+			t.Errorf("failed to move cursor left over %q: expected position 1, got %d", cluster, i.GetCursorPosition())
+		}
+
+		// Move right over the cluster.
+
+		sendInputFieldKey(i, tcell.KeyRight, 0, tcell.ModNone)
+		if expected := 1 + len(cluster); i.GetCursorPosition() != expected {
+			t.Errorf("failed to move cursor right over %q: expected position %d, got %d", cluster, expected, i.GetCursorPosition())
+		}
+
+		// Delete the cluster.
+
+		sendInputFieldKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+		if i.GetText() != "ab" {
+			t.Errorf("failed to delete %q with backspace: expected text ab, got %q", cluster, i.GetText())
+		} else if i.GetCursorPosition() != 1 {
+			t.Errorf("failed to delete %q with backspace: expected position 1, got %d", cluster, i.GetCursorPosition())
+		}
+
+		i.SetText(text)
+		i.SetCursorPosition(1)
+		sendInputFieldKey(i, tcell.KeyDelete, 0, tcell.ModNone)
+		if i.GetText() != "ab" {
+			t.Errorf("failed to delete %q with delete: expected text ab, got %q", cluster, i.GetText())
+		}
+	}
+}
+
+func TestInputFieldInsertText(t *testing.T) {
+	t.Parallel()
+
+	var changed int
+	var changedReason ChangeReason
+	i := NewInputField()
+	i.SetText("ad")
+	i.SetCursorPosition(1)
+	i.SetAcceptanceFunc(func(text string, ch rune) bool {
+		return ch != 'x'
+	})
+	i.SetChangedFuncEx(func(text string, reason ChangeReason) {
+		changed++
+		changedReason = reason
+	})
+
+	i.InsertText("bx\nc")
+	if i.GetText() != "abcd" {
+		t.Errorf("failed to insert text: expected abcd, got %q", i.GetText())
+	} else if i.GetCursorPosition() != 3 {
+		t.Errorf("failed to insert text: expected cursor position 3, got %d", i.GetCursorPosition())
+	} else if changed != 1 {
+		t.Errorf("failed to insert text: expected changed handler to be called once, got %d", changed)
+	} else if changedReason != ChangeReasonPaste {
+		t.Errorf("failed to insert text: expected change reason %d, got %d", ChangeReasonPaste, changedReason)
+	}
+
+	// Pasted keys are passed to the input capture functions.
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'e' {
+			return nil
+		}
+		return event
+	})
+	i.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'f' {
+			return tcell.NewEventKey(tcell.KeyRune, 'F', tcell.ModNone)
+		}
+		return event
+	})
+	i.SetText("")
+	var events []*tcell.EventKey
+	for _, r := range "def" {
+		events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	app.paste(i, events)
+	if i.GetText() != "dF" {
+		t.Errorf("failed to capture pasted keys: expected dF, got %q", i.GetText())
+	}
+}
+
+func TestInputFieldChangeReason(t *testing.T) {
+	t.Parallel()
+
+	var reasons []ChangeReason
+	i := NewInputField()
+	i.SetChangedFuncEx(func(text string, reason ChangeReason) {
+		reasons = append(reasons, reason)
+	})
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText != "ab" {
+			return nil
+		}
+		return []*ListItem{NewListItem("abc")}
+	})
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+	i.SetText("a")
+	i.InsertText("b")
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+
+	expected := []ChangeReason{ChangeReasonUserInput, ChangeReasonDelete, ChangeReasonProgrammatic, ChangeReasonPaste, ChangeReasonAutocomplete}
+	if i.GetText() != "abc" {
+		t.Errorf("failed to select autocomplete entry: expected abc, got %q", i.GetText())
+	} else if len(reasons) != len(expected) {
+		t.Errorf("failed to report change reasons: expected %v, got %v", expected, reasons)
+	} else {
+		for index := range expected {
+			if reasons[index] != expected[index] {
+				t.Errorf("failed to report change reasons: expected %v, got %v", expected, reasons)
+				break
+			}
+		}
+	}
+
+	// Handlers set via SetChangedFunc are still called.
+
+	var changedText string
+	i.SetChangedFunc(func(text string) {
+		changedText = text
+	})
+	sendInputFieldKey(i, tcell.KeyRune, 'd', tcell.ModNone)
+	if changedText != "abcd" {
+		t.Errorf("failed to call changed handler: expected abcd, got %q", changedText)
+	} else if len(reasons) != len(expected) {
+		t.Errorf("failed to replace changed handler: expected %d calls, got %d", len(expected), len(reasons))
+	}
+}
+
+func TestInputFieldAutocompleteDebounce(t *testing.T) {
+	t.Parallel()
+
+	calls := make(chan string, 10)
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText != "" {
+			calls <- currentText
+		}
+		return nil
+	})
+	i.SetAutocompleteDebounce(50 * time.Millisecond)
+
+	for _, ch := range "abc" {
+		sendInputFieldKey(i, tcell.KeyRune, ch, tcell.ModNone)
+	}
+	if len(calls) != 0 {
+		t.Fatal("failed to debounce autocomplete: callback was invoked immediately")
+	}
+
+	// The callback is invoked once the input settled, even without a redraw
+	// handler.
+	select {
+	case text := <-calls:
+		if text != "abc" {
+			t.Errorf("failed to debounce autocomplete: expected text abc, got %q", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed to debounce autocomplete: callback was not invoked")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if len(calls) != 0 {
+		t.Errorf("failed to debounce autocomplete: expected a single invocation, got %d more", len(calls))
+	}
+
+	// The redraw handler is called after the callback was invoked.
+	redraws := make(chan struct{}, 10)
+	i.SetRedrawFunc(func() {
+		redraws <- struct{}{}
+	})
+	sendInputFieldKey(i, tcell.KeyRune, 'd', tcell.ModNone)
+	select {
+	case <-redraws:
+		if len(calls) != 1 {
+			t.Errorf("failed to debounce autocomplete: expected callback before redraw, got %d calls", len(calls))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed to redraw debounced autocomplete entries")
+	}
+}
+
+func TestInputFieldAcceptanceMessage(t *testing.T) {
+	t.Parallel()
+
+	const message = "Only digits allowed"
+
+	i := NewInputField()
+	i.SetText("13")
+	i.SetCursorPosition(1)
+	i.SetAcceptanceFuncEx(func(text string, ch rune) (bool, string) {
+		if ch < '0' || ch > '9' {
+			return false, message
+		}
+		return true, ""
+	})
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if i.GetText() != "13" {
+		t.Errorf("failed to reject character: expected text 13, got %q", i.GetText())
+	} else if string(i.fieldNote) != message {
+		t.Errorf("failed to reject character: expected field note %q, got %q", message, i.fieldNote)
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, '2', tcell.ModNone)
+	if i.GetText() != "123" {
+		t.Errorf("failed to accept character: expected text 123, got %q", i.GetText())
+	} else if len(i.fieldNote) != 0 {
+		t.Errorf("failed to accept character: expected empty field note, got %q", i.fieldNote)
+	}
+
+	// The field note set by the application is restored.
+
+	i.SetFieldNote("Enter a number")
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModNone)
+	if string(i.fieldNote) != message {
+		t.Errorf("failed to reject character: expected field note %q, got %q", message, i.fieldNote)
+	}
+	sendInputFieldKey(i, tcell.KeyRune, '4', tcell.ModNone)
+	if string(i.fieldNote) != "Enter a number" {
+		t.Errorf("failed to restore field note: expected %q, got %q", "Enter a number", i.fieldNote)
+	}
+}
+
+func TestInputFieldFocusFunc(t *testing.T) {
+	t.Parallel()
+
+	var focused, blurred int
+	i := NewInputField()
+	i.SetFocusFunc(func() {
+		focused++
+	})
+	i.SetBlurFunc(func() {
+		blurred++
+	})
+
+	i.Focus(func(p Primitive) {})
+	i.Focus(func(p Primitive) {})
+	if focused != 1 {
+		t.Errorf("failed to call focus handler: expected 1 call, got %d", focused)
+	}
+
+	i.Blur()
+	i.Blur()
+	if blurred != 1 {
+		t.Errorf("failed to call blur handler: expected 1 call, got %d", blurred)
+	}
+}
+
+func TestInputFieldSelectAllOnFocus(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello")
+	i.SetCursorPosition(0)
+	i.SetSelectAllOnFocus(true)
+
+	i.Focus(func(p Primitive) {})
+	if i.GetCursorPosition() != 5 {
+		t.Errorf("failed to select text on focus: expected cursor position 5, got %d", i.GetCursorPosition())
+	}
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.SetRect(0, 0, 10, 1)
+	i.Draw(app.screen)
+	_, _, style, _ := app.screen.GetContent(0, 0)
+	if _, bg, _ := style.Decompose(); bg != Styles.PrimaryTextColor {
+		t.Errorf("failed to draw selected text: expected background %v, got %v", Styles.PrimaryTextColor, bg)
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if i.GetText() != "a" {
+		t.Errorf("failed to replace selected text: expected text a, got %q", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModNone)
+	if i.GetText() != "ab" {
+		t.Errorf("failed to enter text after replacing selected text: expected text ab, got %q", i.GetText())
+	}
+
+	// Pasted text replaces the selected text.
+
+	i.Blur()
+	i.SetText("old")
+	i.Focus(func(p Primitive) {})
+	i.SetAcceptanceFunc(func(text string, ch rune) bool {
+		return ch != 'x'
+	})
+	i.InsertText("x")
+	if i.GetText() != "old" || !i.GetState().SelectedAll {
+		t.Errorf("failed to keep selected text after rejected paste: expected old and selected, got %q and %t", i.GetText(), i.GetState().SelectedAll)
+	}
+	i.InsertText("new")
+	if i.GetText() != "new" || i.GetState().SelectedAll {
+		t.Errorf("failed to replace selected text with pasted text: expected new and not selected, got %q and %t", i.GetText(), i.GetState().SelectedAll)
+	}
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if i.GetText() != "newa" {
+		t.Errorf("failed to enter text after pasting: expected newa, got %q", i.GetText())
+	}
+}
+
+func TestInputFieldAutocompleteNavigation(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		var entries []*ListItem
+		for _, entry := range []string{"a1", "a2", "a3", "a4"} {
+			entries = append(entries, NewListItem(entry))
+		}
+		return entries
+	})
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+
+	sendInputFieldKey(i, tcell.KeyEnd, 0, tcell.ModNone)
+	if index := i.autocompleteList.GetCurrentItemIndex(); index != 3 {
+		t.Errorf("failed to move to last autocomplete entry: expected index 3, got %d", index)
+	} else if i.GetCursorPosition() != 1 {
+		t.Errorf("failed to move to last autocomplete entry: expected cursor position 1, got %d", i.GetCursorPosition())
+	}
+
+	sendInputFieldKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	if index := i.autocompleteList.GetCurrentItemIndex(); index != 0 {
+		t.Errorf("failed to move to first autocomplete entry: expected index 0, got %d", index)
+	}
+
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	if i.GetCursorPosition() != 0 {
+		t.Errorf("failed to move cursor home: expected cursor position 0, got %d", i.GetCursorPosition())
+	}
+}
+
+func TestInputFieldAcceptSuggestion(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		return []*ListItem{NewListItem("apple")}
+	})
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+
+	sendInputFieldKey(i, tcell.KeyLeft, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRight, 0, tcell.ModNone)
+	if i.GetText() != "a" {
+		t.Errorf("failed to move cursor right: expected text a, got %q", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyRight, 0, tcell.ModNone)
+	if i.GetText() != "apple" {
+		t.Errorf("failed to accept suggestion: expected text apple, got %q", i.GetText())
+	} else if i.GetCursorPosition() != 5 {
+		t.Errorf("failed to accept suggestion: expected cursor position 5, got %d", i.GetCursorPosition())
+	} else if i.autocompleteList == nil {
+		t.Errorf("failed to accept suggestion: expected autocomplete list to remain open")
+	}
+}
+
+func TestInputFieldOverflowIndicator(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetFieldWidth(10)
+	i.SetOverflowIndicator('…')
+	i.SetText("abcdefghijklmnopqrst")
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != '…' {
+		t.Errorf("failed to draw left overflow indicator: expected …, got %c", r)
+	} else if r, _, _, _ := app.screen.GetContent(9, 0); r == '…' {
+		t.Errorf("failed to draw text: expected no right overflow indicator")
+	} else if r, _, _, _ := app.screen.GetContent(7, 0); r != 't' {
+		t.Errorf("failed to draw text: expected last character t, got %c", r)
+	} else if x, _ := i.GetCursorScreenPosition(); x != 8 {
+		t.Errorf("failed to position cursor: expected x 8, got %d", x)
+	}
+
+	i.SetCursorPosition(0)
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r == '…' {
+		t.Errorf("failed to draw text: expected no left overflow indicator")
+	} else if r, _, _, _ := app.screen.GetContent(9, 0); r != '…' {
+		t.Errorf("failed to draw right overflow indicator: expected …, got %c", r)
+	} else if x, _ := i.GetCursorScreenPosition(); x != 1 {
+		t.Errorf("failed to position cursor: expected x 1, got %d", x)
+	}
+}
+
+func TestInputFieldStrengthFunc(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetStrengthFunc(func(text string) (int, string) {
+		return len(text), "Strength"
+	})
+	if i.GetFieldHeight() != 1 {
+		t.Errorf("failed to get field height: expected 1 without mask, got %d", i.GetFieldHeight())
+	}
+
+	i.SetMaskCharacter('*')
+	i.SetText("ab")
+	i.SetRect(0, 0, 20, 2)
+	if i.GetFieldHeight() != 2 {
+		t.Errorf("failed to get field height: expected 2 with mask, got %d", i.GetFieldHeight())
+	}
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.Draw(app.screen)
+	for x, expected := range []rune{'█', '█', '█', '░', '░', ' ', 'S'} {
+		if r, _, _, _ := app.screen.GetContent(x, 1); r != expected {
+			t.Errorf("failed to draw strength meter: expected %c at %d, got %c", expected, x, r)
+		}
+	}
+
+	i.SetStrengthColors([]tcell.Color{tcell.ColorRed, tcell.ColorGreen}, tcell.ColorBlue)
+	i.SetStrengthRunes('#', '-')
+	i.Draw(app.screen)
+	for x, expected := range []rune{'#', '#', ' ', 'S'} {
+		if r, _, _, _ := app.screen.GetContent(x, 1); r != expected {
+			t.Errorf("failed to draw custom strength meter: expected %c at %d, got %c", expected, x, r)
+		}
+	}
+	_, _, style, _ := app.screen.GetContent(0, 1)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorGreen {
+		t.Errorf("failed to draw custom strength meter: expected color %v, got %v", tcell.ColorGreen, fg)
+	}
+}
+
+func TestInputFieldAlign(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetFieldWidth(10)
+	i.SetPlaceholder("abc")
+	i.SetFieldAlign(AlignRight)
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(7, 0); r != 'a' {
+		t.Errorf("failed to align placeholder to the right: expected a at column 7, got %c", r)
+	}
+
+	// Leave room for the cursor after right-aligned text.
+
+	i.SetText("12")
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(7, 0); r != '1' {
+		t.Errorf("failed to align text to the right: expected 1 at column 7, got %c", r)
+	} else if x, _ := i.GetCursorScreenPosition(); x != 9 {
+		t.Errorf("failed to position cursor: expected x 9, got %d", x)
+	}
+
+	i.SetFieldAlign(AlignCenter)
+	i.SetCursorPosition(1)
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(3, 0); r != '1' {
+		t.Errorf("failed to center text: expected 1 at column 3, got %c", r)
+	} else if x, _ := i.GetCursorScreenPosition(); x != 4 {
+		t.Errorf("failed to position cursor: expected x 4, got %d", x)
+	}
+
+	// Text which does not fit is scrolled as if it was left-aligned.
+
+	i.SetFieldAlign(AlignRight)
+	i.SetText("abcdefghijklmno")
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'g' {
+		t.Errorf("failed to scroll overflowing text: expected g at column 0, got %c", r)
+	} else if x, _ := i.GetCursorScreenPosition(); x != 9 {
+		t.Errorf("failed to position cursor: expected x 9, got %d", x)
+	}
+}
+
+func TestInputFieldTextWidth(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	for text, expected := range map[string]int{
+		"abc":               3,
+		"日本":                4,
+		testInputFieldAcute: 1,
+	} {
+		i.SetText(text)
+		if width := i.GetTextWidth(); width != expected {
+			t.Errorf("failed to get width of %q: expected %d, got %d", text, expected, width)
+		}
+	}
+}
+
+func TestInputFieldTextLength(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	for _, test := range []struct {
+		text         string
+		runes, bytes int
+	}{
+		{"", 0, 0},
+		{"abc", 3, 3},
+		{"日本", 2, 6},
+		{testInputFieldAcute, 2, 3},
+	} {
+		i.SetText(test.text)
+		if runes := i.GetRuneCount(); runes != test.runes {
+			t.Errorf("failed to get rune count of %q: expected %d, got %d", test.text, test.runes, runes)
+		}
+		if bytes := i.GetByteLength(); bytes != test.bytes {
+			t.Errorf("failed to get byte length of %q: expected %d, got %d", test.text, test.bytes, bytes)
+		}
+	}
+}
+
+func TestInputFieldKeybindings(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello world")
+
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModAlt)
+	if i.GetCursorPosition() != 6 {
+		t.Errorf("failed to move word left: expected cursor position 6, got %d", i.GetCursorPosition())
+	}
+
+	sendInputFieldKey(i, tcell.KeyCtrlK, 0, tcell.ModCtrl)
+	if i.GetText() != "hello " {
+		t.Errorf("failed to delete to end: expected text \"hello \", got %q", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyCtrlA, 0, tcell.ModCtrl)
+	if i.GetCursorPosition() != 0 {
+		t.Errorf("failed to move to line start: expected cursor position 0, got %d", i.GetCursorPosition())
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'e', tcell.ModAlt)
+	if i.GetCursorPosition() != 6 {
+		t.Errorf("failed to move to line end: expected cursor position 6, got %d", i.GetCursorPosition())
+	}
+}
+
+func TestInputFieldVimMode(t *testing.T) {
+	t.Parallel()
+
+	var modes []EditMode
+	i := NewInputField()
+	i.SetText("hello world")
+	i.SetEditModeChangedFunc(func(mode EditMode) {
+		modes = append(modes, mode)
+	})
+	i.SetVimMode(true)
+	if i.GetEditMode() != EditModeNormal {
+		t.Errorf("failed to enable vim mode: expected normal mode, got %d", i.GetEditMode())
+	}
+
+	// Normal mode
+
+	for _, ch := range "bhx" {
+		sendInputFieldKey(i, tcell.KeyRune, ch, tcell.ModNone)
+	}
+	if i.GetText() != "helloworld" {
+		t.Errorf("failed to process normal mode commands: expected text helloworld, got %q", i.GetText())
+	} else if i.GetCursorPosition() != 5 {
+		t.Errorf("failed to process normal mode commands: expected cursor position 5, got %d", i.GetCursorPosition())
+	}
+
+	// Insert mode
+
+	for _, ch := range "i, " {
+		sendInputFieldKey(i, tcell.KeyRune, ch, tcell.ModNone)
+	}
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRune, 'x', tcell.ModNone)
+	if i.GetText() != "hello, orld" {
+		t.Errorf("failed to process insert mode: expected text \"hello, orld\", got %q", i.GetText())
+	}
+
+	if len(modes) != 3 || modes[0] != EditModeNormal || modes[1] != EditModeInsert || modes[2] != EditModeNormal {
+		t.Errorf("failed to call edit mode changed handler: expected modes [1 0 1], got %v", modes)
+	}
+}
+
+func TestInputFieldSetTextUnchanged(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello")
+
+	var changed int
+	i.SetChangedFunc(func(text string) {
+		changed++
+	})
+
+	i.SetText("hello")
+	i.SetTextWithCursor("hello", 2)
+	if changed != 0 {
+		t.Errorf("failed to set unchanged text: expected changed handler not to be called, got %d calls", changed)
+	} else if i.GetCursorPosition() != 2 {
+		t.Errorf("failed to set unchanged text: expected position 2, got %d", i.GetCursorPosition())
+	}
+
+	i.SetText("world")
+	if changed != 1 {
+		t.Errorf("failed to set text: expected changed handler to be called once, got %d", changed)
+	}
+}
+
+func TestInputFieldState(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello world")
+	i.SetCursorPosition(5)
+
+	state := i.GetState()
+	if state.Text != "hello world" || state.CursorPosition != 5 || state.SelectedAll {
+		t.Errorf("failed to get state: expected {hello world 5 false}, got %+v", state)
+	}
+
+	i.SetText("changed")
+
+	i.SetState(state)
+	if i.GetText() != "hello world" {
+		t.Errorf("failed to restore state: expected text hello world, got %s", i.GetText())
+	} else if i.GetCursorPosition() != 5 {
+		t.Errorf("failed to restore state: expected position 5, got %d", i.GetCursorPosition())
+	}
+
+	// Out of range positions are clamped.
+
+	i.SetState(InputFieldState{Text: "abc", CursorPosition: 10, Offset: -1})
+	if state := i.GetState(); state.CursorPosition != 3 || state.Offset != 0 {
+		t.Errorf("failed to clamp state: expected position 3 and offset 0, got %d and %d", state.CursorPosition, state.Offset)
+	}
+}
+
+func TestInputFieldMaskedSuggestion(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetMaskCharacter('*')
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		return []*ListItem{NewListItem("apple")}
+	})
+	i.SetRect(0, 0, 10, 1)
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// The suggestion is hidden by default.
+
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(1, 0); r != ' ' {
+		t.Errorf("failed to hide masked suggestion: expected blank, got %c", r)
+	}
+
+	i.SetShowSuggestionWhenMasked(true)
+	i.Draw(app.screen)
+	for x := 1; x < 5; x++ {
+		if r, _, _, _ := app.screen.GetContent(x, 0); r != '*' {
+			t.Errorf("failed to draw masked suggestion: expected * at %d, got %c", x, r)
+		}
+	}
+}
+
+func TestInputFieldScrollTo(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("abcdefghijklmnopqrst")
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if i.GetScrollOffset() == 0 {
+		t.Errorf("failed to scroll to cursor: expected offset > 0, got 0")
+	}
+
+	// The offset is kept while the cursor does not move.
+
+	i.ScrollTo(2)
+	i.Draw(app.screen)
+	if i.GetScrollOffset() != 2 {
+		t.Errorf("failed to scroll: expected offset 2, got %d", i.GetScrollOffset())
+	} else if r, _, _, _ := app.screen.GetContent(0, 0); r != 'c' {
+		t.Errorf("failed to draw scrolled text: expected c, got %c", r)
+	}
+
+	// Moving the cursor scrolls it back into view.
+
+	sendInputFieldKey(i, tcell.KeyLeft, 0, tcell.ModNone)
+	i.Draw(app.screen)
+	if i.GetScrollOffset() <= 2 {
+		t.Errorf("failed to scroll to cursor: expected offset > 2, got %d", i.GetScrollOffset())
+	}
+
+	i.ScrollTo(100)
+	if i.GetScrollOffset() != 20 {
+		t.Errorf("failed to clamp scroll offset: expected 20, got %d", i.GetScrollOffset())
+	}
+}
+
+func TestInputFieldFinishOnBlur(t *testing.T) {
+	t.Parallel()
+
+	var keys []tcell.Key
+	i := NewInputField()
+	i.SetDoneFunc(func(key tcell.Key) {
+		keys = append(keys, key)
+	})
+
+	// Disabled by default.
+
+	i.Focus(func(p Primitive) {})
+	i.Blur()
+	if len(keys) != 0 {
+		t.Errorf("failed to ignore blur: expected no done calls, got %v", keys)
+	}
+
+	i.SetFinishOnBlur(true)
+	i.Focus(func(p Primitive) {})
+	i.Blur()
+	if len(keys) != 1 || keys[0] != tcell.KeyNUL {
+		t.Errorf("failed to finish on blur: expected [%d], got %v", tcell.KeyNUL, keys)
+	}
+
+	// Input finished by a key is not finished again.
+
+	keys = nil
+	i.Focus(func(p Primitive) {})
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	i.Blur()
+	if len(keys) != 1 || keys[0] != tcell.KeyEnter {
+		t.Errorf("failed to finish on blur: expected [%d], got %v", tcell.KeyEnter, keys)
+	}
+
+	// Handlers may shift the focus.
+
+	other, next := NewInputField(), NewInputField()
+	flex := NewFlex()
+	flex.AddItem(i, 1, 0, true)
+	flex.AddItem(other, 1, 0, false)
+	flex.AddItem(next, 1, 0, false)
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	i.SetFormatFunc(strings.ToUpper)
+	i.SetChangedFunc(func(text string) {
+		app.GetFocus()
+	})
+	i.SetDoneFunc(func(key tcell.Key) {
+		app.SetFocus(next)
+	})
+	i.SetText("x")
+
+	blurred := make(chan struct{})
+	go func() {
+		app.SetFocus(other)
+		close(blurred)
+	}()
+	select {
+	case <-blurred:
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed to finish on blur: deadlock when calling Application from handlers")
+	}
+	if app.GetFocus() != next {
+		t.Errorf("failed to shift focus from done handler: expected next field, got %v", app.GetFocus())
+	} else if i.GetText() != "X" {
+		t.Errorf("failed to format text on blur: expected X, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldTaggedLabel(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetLabel("[red]*[-]Name ")
+	i.SetText("x")
+	i.SetRect(0, 0, 20, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if r, _, style, _ := app.screen.GetContent(0, 0); r != '*' {
+		t.Errorf("failed to draw tagged label: expected *, got %c", r)
+	} else if fg, _, _ := style.Decompose(); fg != tcell.ColorRed {
+		t.Errorf("failed to draw tagged label: expected red, got %v", fg)
+	}
+	if r, _, _, _ := app.screen.GetContent(1, 0); r != 'N' {
+		t.Errorf("failed to draw tagged label: expected N, got %c", r)
+	}
+	if r, _, _, _ := app.screen.GetContent(6, 0); r != 'x' {
+		t.Errorf("failed to position input area after tagged label: expected x at 6, got %c", r)
+	}
+
+	i.SetText("")
+	i.SetPlaceholder("[green]Jane[-] Doe")
+	i.Draw(app.screen)
+	if r, _, style, _ := app.screen.GetContent(6, 0); r != 'J' {
+		t.Errorf("failed to draw tagged placeholder: expected J, got %c", r)
+	} else if fg, _, _ := style.Decompose(); fg != tcell.ColorGreen {
+		t.Errorf("failed to draw tagged placeholder: expected green, got %v", fg)
+	}
+}
+
+func TestInputFieldAutocompleteEntryStyler(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		return []*ListItem{NewListItem("apple"), NewListItem("---"), NewListItem("apricot")}
+	})
+	var styled []string
+	i.SetAutocompleteEntryStyler(func(index int, item *ListItem) {
+		styled = append(styled, i.GetText())
+		if index == 1 {
+			item.SetEnabled(false)
+		}
+	})
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if len(styled) != 3 || styled[0] != "a" {
+		t.Fatalf("failed to style autocomplete entries: expected 3 calls with text a, got %v", styled)
+	}
+
+	for _, test := range []struct {
+		key      tcell.Key
+		expected int
+	}{
+		{tcell.KeyDown, 2},
+		{tcell.KeyDown, 0},
+		{tcell.KeyUp, 2},
+		{tcell.KeyUp, 0},
+	} {
+		sendInputFieldKey(i, test.key, 0, tcell.ModNone)
+		if index := i.autocompleteList.GetCurrentItemIndex(); index != test.expected {
+			t.Errorf("failed to skip disabled autocomplete entry: expected %d, got %d", test.expected, index)
+		}
+	}
+
+	i.autocompleteList.SetCurrentItem(1)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if i.GetText() != "a" {
+		t.Errorf("failed to reject disabled autocomplete entry: expected a, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldAutocompleteList(t *testing.T) {
+	t.Parallel()
+
+	var configured *List
+	i := NewInputField()
+	i.SetAutocompleteListConfigureFunc(func(l *List) {
+		configured = l
+		l.SetScrollBarVisibility(ScrollBarNever)
+	})
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		return []*ListItem{NewListItem("apple"), NewListItem("apricot")}
+	})
+
+	if i.GetAutocompleteList() != nil {
+		t.Errorf("failed to get autocomplete list: expected nil before autocompletion")
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if l := i.GetAutocompleteList(); l == nil || l != configured {
+		t.Errorf("failed to configure autocomplete list: expected configured list, got %v", l)
+	} else if l.GetItemCount() != 2 {
+		t.Errorf("failed to get autocomplete list: expected 2 items, got %d", l.GetItemCount())
+	}
+}
+
+func TestInputFieldAutocompleteShowList(t *testing.T) {
+	t.Parallel()
+
+	var configured int
+	i := NewInputField()
+	i.SetAutocompleteShowList(false)
+	i.SetAutocompleteListConfigureFunc(func(l *List) {
+		configured++
+	})
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		avocado := NewListItem("avocado")
+		avocado.SetEnabled(false)
+		return []*ListItem{avocado, NewListItem("apple"), NewListItem("apricot")}
+	})
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.SetRect(0, 0, 10, 1)
+	i.Focus(func(p Primitive) {})
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if i.GetAutocompleteList() != nil || configured != 0 {
+		t.Errorf("failed to hide autocomplete list: expected no list, got %d configured lists", configured)
+	}
+	i.Draw(app.screen)
+	var drawn []rune
+	for x := 0; x < 5; x++ {
+		ch, _, _, _ := app.screen.GetContent(x, 0)
+		drawn = append(drawn, ch)
+	}
+	if string(drawn) != "apple" {
+		t.Errorf("failed to draw suggestion of first enabled autocomplete entry: expected apple, got %s", string(drawn))
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'p', tcell.ModNone)
+	i.Draw(app.screen)
+	if i.GetAutocompleteList() != nil || configured != 0 {
+		t.Errorf("failed to hide autocomplete list: expected no list, got %d configured lists", configured)
+	}
+
+	i.SetAutocompleteShowList(true)
+	sendInputFieldKey(i, tcell.KeyRune, 'r', tcell.ModNone)
+	if l := i.GetAutocompleteList(); l == nil || configured != 1 {
+		t.Errorf("failed to show autocomplete list: expected 1 configured list, got %d", configured)
+	}
+}
+
+func TestInputFieldAutocompleteOverflowCount(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteMaxHeight(3)
+	i.SetAutocompleteShowOverflowCount(true)
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		var entries []*ListItem
+		for _, entry := range []string{"a1", "a2", "a3", "a4", "a5"} {
+			entries = append(entries, NewListItem(entry))
+		}
+		return entries
+	})
+	i.SetRect(0, 0, 20, 1)
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if _, _, _, height := i.GetAutocompleteList().GetRect(); height != 2 {
+		t.Errorf("failed to reserve overflow row: expected list height 2, got %d", height)
+	}
+
+	var footer []rune
+	for x := 0; x < 12; x++ {
+		r, _, _, _ := app.screen.GetContent(x, 3)
+		footer = append(footer, r)
+	}
+	if string(footer) != "… and 3 more" {
+		t.Errorf("failed to draw overflow count: expected \"… and 3 more\", got %q", string(footer))
+	}
+}
+
+func TestNewPasswordField(t *testing.T) {
+	t.Parallel()
+
+	i := NewPasswordField('*')
+	i.SetText("secret")
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != '*' {
+		t.Errorf("failed to mask password field: expected *, got %c", r)
+	} else if i.GetText() != "secret" {
+		t.Errorf("failed to get password field text: expected secret, got %s", i.GetText())
+	}
+
+	i.SetCursorPosition(0)
+	sendInputFieldKey(i, tcell.KeyCtrlK, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyCtrlY, 0, tcell.ModNone)
+	if i.GetText() != "" {
+		t.Errorf("failed to keep deleted password out of kill ring: expected empty text, got %s", i.GetText())
+	}
+
+	i.SetAllowCopyWhenMasked(true)
+	i.SetText("secret")
+	i.SetCursorPosition(0)
+	sendInputFieldKey(i, tcell.KeyCtrlK, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyCtrlY, 0, tcell.ModNone)
+	if i.GetText() != "secret" {
+		t.Errorf("failed to yank deleted password: expected secret, got %s", i.GetText())
+	}
+}
+
+func TestInputFieldAutocompleteAsync(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		ctx      context.Context
+		text     string
+		callback func(entries []*ListItem)
+	}
+	requests := make(chan request, 10)
+
+	i := NewInputField()
+	i.SetAutocompleteFuncAsync(func(ctx context.Context, currentText string, callback func(entries []*ListItem)) {
+		if currentText != "" {
+			requests <- request{ctx, currentText, callback}
+		}
+	})
+
+	receive := func() request {
+		select {
+		case r := <-requests:
+			return r
+		case <-time.After(time.Second):
+			t.Fatal("failed to request autocomplete entries: callback was not invoked")
+		}
+		return request{}
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	first := receive()
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModNone)
+	second := receive()
+
+	if first.ctx.Err() == nil {
+		t.Errorf("failed to cancel superseded autocomplete request")
+	} else if second.text != "ab" {
+		t.Errorf("failed to request autocomplete entries: expected text ab, got %q", second.text)
+	}
+
+	// Entries of canceled requests are discarded.
+
+	first.callback([]*ListItem{NewListItem("a1")})
+	if i.GetAutocompleteList() != nil {
+		t.Errorf("failed to discard entries of canceled autocomplete request")
+	}
+
+	second.callback([]*ListItem{NewListItem("ab1"), NewListItem("ab2")})
+	if l := i.GetAutocompleteList(); l == nil || l.GetItemCount() != 2 {
+		t.Errorf("failed to apply asynchronous autocomplete entries")
+	}
+
+	// Escape cancels the pending request.
+
+	sendInputFieldKey(i, tcell.KeyRune, 'c', tcell.ModNone)
+	third := receive()
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone)
+	if third.ctx.Err() == nil {
+		t.Errorf("failed to cancel autocomplete request on Escape")
+	}
+}
+
+func TestInputFieldHidePlaceholderOnFocus(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetPlaceholder("Search")
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	i.Focus(func(p Primitive) {})
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'S' {
+		t.Errorf("failed to draw placeholder while focused: expected S, got %c", r)
+	}
+
+	i.SetHidePlaceholderOnFocus(true)
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != ' ' {
+		t.Errorf("failed to hide placeholder while focused: expected blank, got %c", r)
+	}
+
+	i.Blur()
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'S' {
+		t.Errorf("failed to draw placeholder after blur: expected S, got %c", r)
+	}
+}
+
+func TestInputFieldPlaceholderRotation(t *testing.T) {
+	t.Parallel()
+
+	redraws := make(chan struct{}, 100)
+	i := NewInputField()
+	i.SetPlaceholder("Search")
+	i.SetRect(0, 0, 10, 1)
+	i.SetRedrawFunc(func() {
+		redraws <- struct{}{}
+	})
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	i.Blur()
+
+	i.SetPlaceholderRotation([]string{"First", "Second"}, 10*time.Millisecond)
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'F' {
+		t.Errorf("failed to draw rotating placeholder: expected F, got %c", r)
+	}
+
+	select {
+	case <-redraws:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to rotate placeholder: no redraw")
+	}
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(0, 0); r != 'S' {
+		t.Errorf("failed to rotate placeholder: expected S, got %c", r)
+	}
+
+	// Focusing the field stops the rotation and shows the regular placeholder.
+
+	i.Focus(func(p Primitive) {})
+	i.RLock()
+	stopped := i.placeholderRotationStop == nil
+	i.RUnlock()
+	if !stopped {
+		t.Errorf("failed to stop placeholder rotation on focus")
+	}
+	i.Draw(app.screen)
+	if r, _, _, _ := app.screen.GetContent(1, 0); r != 'e' {
+		t.Errorf("failed to draw placeholder while focused: expected e, got %c", r)
+	}
+
+	// Drawing the field after it lost focus resumes the rotation.
+
+	i.Blur()
+	for len(redraws) > 0 {
+		<-redraws
+	}
+	i.Draw(app.screen)
+	select {
+	case <-redraws:
+	case <-time.After(5 * time.Second):
+		t.Errorf("failed to resume placeholder rotation after blur")
+	}
+
+	// Entering text stops the rotation once the next tick notices it.
+
+	i.SetText("a")
+	i.Draw(app.screen)
+	time.Sleep(50 * time.Millisecond)
+	i.RLock()
+	stopped = i.placeholderRotationStop == nil
+	i.RUnlock()
+	if !stopped {
+		t.Errorf("failed to stop placeholder rotation when text was entered")
+	}
+	for len(redraws) > 0 {
+		<-redraws
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(redraws) != 0 {
+		t.Errorf("failed to stop redrawing: expected no redraws while text is entered, got %d", len(redraws))
+	}
+
+	// The rotation stops when the field is no longer drawn.
+
+	i.SetText("")
+	i.Draw(app.screen)
+	time.Sleep(50 * time.Millisecond)
+	i.RLock()
+	stopped = i.placeholderRotationStop == nil
+	i.RUnlock()
+	if !stopped {
+		t.Errorf("failed to stop placeholder rotation when the field was not drawn")
+	}
+	i.SetPlaceholderRotation(nil, 0)
+}
+
+func TestInputFieldMaskReveal(t *testing.T) {
+	t.Parallel()
+
+	redraws := make(chan struct{}, 10)
+	i := NewInputField()
+	i.SetMaskCharacter('*')
+	i.SetRect(0, 0, 10, 1)
+	i.SetRedrawFunc(func() {
+		redraws <- struct{}{}
+	})
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	drawn := func() string {
+		i.Draw(app.screen)
+		var b strings.Builder
+		for x := 0; x < 3; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Without a reveal duration characters are masked immediately.
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	if s := drawn(); s != "*  " {
+		t.Errorf("failed to mask character: expected \"*  \", got %q", s)
+	}
+
+	i.SetMaskRevealDuration(20 * time.Millisecond)
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModNone)
+	if s := drawn(); s != "*b " {
+		t.Errorf("failed to reveal character: expected \"*b \", got %q", s)
+	}
+
+	select {
+	case <-redraws:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("failed to mask revealed character: no redraw")
+	}
+	if s := drawn(); s != "** " {
+		t.Errorf("failed to mask revealed character: expected \"** \", got %q", s)
+	}
+
+	// Moving the cursor masks the character immediately.
+
+	sendInputFieldKey(i, tcell.KeyRune, 'c', tcell.ModNone)
+	if s := drawn(); s != "**c" {
+		t.Errorf("failed to reveal character: expected \"**c\", got %q", s)
+	}
+	sendInputFieldKey(i, tcell.KeyLeft, 0, tcell.ModNone)
+	if s := drawn(); s != "***" {
+		t.Errorf("failed to mask character on cursor movement: expected \"***\", got %q", s)
+	}
+}
+
+func TestInputFieldNumericStep(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("1")
+	sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+	if i.GetText() != "1" {
+		t.Errorf("failed to ignore Up without a numeric step: expected 1, got %q", i.GetText())
+	}
+
+	i.SetNumericStep(0.25)
+	i.SetNumericBounds(0, 1.5)
+	sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+	if i.GetText() != "1.25" {
+		t.Errorf("failed to step up: expected 1.25, got %q", i.GetText())
+	}
+	sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+	if i.GetText() != "1.5" {
+		t.Errorf("failed to limit value to maximum: expected 1.5, got %q", i.GetText())
+	}
+
+	i.SetText("0.5")
+	sendInputFieldKey(i, tcell.KeyDown, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyDown, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyDown, 0, tcell.ModNone)
+	if i.GetText() != "0" {
+		t.Errorf("failed to limit value to minimum: expected 0, got %q", i.GetText())
+	}
+
+	// Results are passed to the acceptance function.
+
+	i.SetAcceptanceFunc(func(text string, ch rune) bool {
+		return text != "0.25"
+	})
+	sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+	if i.GetText() != "0" {
+		t.Errorf("failed to reject stepped value: expected 0, got %q", i.GetText())
+	}
+	i.SetAcceptanceFunc(nil)
+
+	// Text which is not a finite number is not stepped.
+
+	for _, text := range []string{"a", "Inf", "-inf", "NaN"} {
+		i.SetText(text)
+		sendInputFieldKey(i, tcell.KeyUp, 0, tcell.ModNone)
+		if i.GetText() != text {
+			t.Errorf("failed to ignore non-numeric text: expected %s, got %q", text, i.GetText())
+		}
+	}
+}
+
+func TestInputFieldTranspose(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		text        string
+		cursorPos   int
+		expected    string
+		expectedPos int
+	}{
+		{"ab", 2, "ba", 2},
+		{"abc", 1, "bac", 2},
+		{"abc", 0, "abc", 0},
+		{"a", 1, "a", 1},
+		{"a" + testInputFieldFamily + "b", 1, testInputFieldFamily + "ab", 1 + len(testInputFieldFamily)},
+		{"é" + testInputFieldAcute, len("é" + testInputFieldAcute), testInputFieldAcute + "é", len(testInputFieldAcute + "é")},
+	} {
+		i := NewInputField()
+		i.SetText(test.text)
+		i.SetCursorPosition(test.cursorPos)
+		sendInputFieldKey(i, tcell.KeyCtrlT, 0, tcell.ModCtrl)
+		if i.GetText() != test.expected {
+			t.Errorf("failed to transpose %q at %d: expected %q, got %q", test.text, test.cursorPos, test.expected, i.GetText())
+		} else if i.GetCursorPosition() != test.expectedPos {
+			t.Errorf("failed to transpose %q at %d: expected position %d, got %d", test.text, test.cursorPos, test.expectedPos, i.GetCursorPosition())
+		}
+	}
+}
+
+func TestInputFieldYank(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello world")
+
+	// Kill the last word, then the rest of the line.
+
+	sendInputFieldKey(i, tcell.KeyCtrlW, 0, tcell.ModCtrl)
+	sendInputFieldKey(i, tcell.KeyCtrlU, 0, tcell.ModCtrl)
+	if i.GetText() != "" {
+		t.Errorf("failed to delete text: expected empty text, got %q", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	if i.GetText() != "hello " {
+		t.Errorf("failed to yank text: expected \"hello \", got %q", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'y', tcell.ModAlt)
+	if i.GetText() != "world" {
+		t.Errorf("failed to cycle yanked text: expected world, got %q", i.GetText())
+	} else if i.GetCursorPosition() != 5 {
+		t.Errorf("failed to cycle yanked text: expected position 5, got %d", i.GetCursorPosition())
+	}
+
+	// Yanked text passes through the acceptance function.
+
+	i.SetText("")
+	i.SetAcceptanceFunc(InputFieldInteger)
+	sendInputFieldKey(i, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	if i.GetText() != "" {
+		t.Errorf("failed to reject yanked text: expected empty text, got %q", i.GetText())
+	}
+
+	// Alt+y is processed like any other key when it does not follow a yank.
+
+	i.SetAcceptanceFunc(nil)
+	i.SetText("a")
+	sendInputFieldKey(i, tcell.KeyRune, 'y', tcell.ModAlt)
+	if i.GetText() != "ay" {
+		t.Errorf("failed to pass through Alt+y: expected ay, got %q", i.GetText())
+	}
+}
+
+func TestInputFieldWordCase(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		ch          rune
+		expected    string
+		expectedPos int
+	}{
+		{'u', "hello, WORLD wide", 12},
+		{'l', "hello, world wide", 12},
+		{'c', "hello, World wide", 12},
+	} {
+		i := NewInputField()
+		i.SetText("hello, wORLD wide")
+		i.SetCursorPosition(5)
+		sendInputFieldKey(i, tcell.KeyRune, test.ch, tcell.ModAlt)
+		if i.GetText() != test.expected {
+			t.Errorf("failed to change word case with Alt-%c: expected %q, got %q", test.ch, test.expected, i.GetText())
+		} else if i.GetCursorPosition() != test.expectedPos {
+			t.Errorf("failed to change word case with Alt-%c: expected position %d, got %d", test.ch, test.expectedPos, i.GetCursorPosition())
+		}
+	}
+
+	// Case changes which alter the length of the text.
+
+	i := NewInputField()
+	i.SetText("ıx y")
+	i.SetCursorPosition(0)
+	sendInputFieldKey(i, tcell.KeyRune, 'u', tcell.ModAlt)
+	if i.GetText() != "IX y" {
+		t.Errorf("failed to uppercase word: expected \"IX y\", got %q", i.GetText())
+	} else if i.GetCursorPosition() != 2 {
+		t.Errorf("failed to uppercase word: expected position 2, got %d", i.GetCursorPosition())
+	}
+}
+
+// cursorStyleScreen records the cursor style set on a screen.
+type cursorStyleScreen struct {
+	tcell.Screen
+
+	style tcell.CursorStyle
+}
+
+func (s *cursorStyleScreen) SetCursorStyle(style tcell.CursorStyle) {
+	s.style = style
+}
+
+func TestInputFieldCursorStyle(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	if i.GetCursorStyle() != tcell.CursorStyleDefault {
+		t.Errorf("failed to get cursor style: expected default, got %d", i.GetCursorStyle())
+	}
+	i.SetCursorStyle(tcell.CursorStyleSteadyBar)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	screen := &cursorStyleScreen{Screen: app.screen, style: tcell.CursorStyleBlinkingBlock}
+
+	// Unfocused
+
+	i.Blur()
+	i.Draw(screen)
+	if screen.style != tcell.CursorStyleBlinkingBlock {
+		t.Errorf("failed to draw unfocused input field: expected cursor style to be unchanged, got %d", screen.style)
+	}
+
+	// Focused
+
+	i.Focus(func(p Primitive) {})
+	i.Draw(screen)
+	if screen.style != tcell.CursorStyleSteadyBar {
+		t.Errorf("failed to set cursor style: expected %d, got %d", tcell.CursorStyleSteadyBar, screen.style)
+	}
+
+	// Moving the focus to a primitive without a cursor resets the style.
+
+	app.Lock()
+	app.screen = screen
+	app.Unlock()
+	app.SetFocus(NewBox())
+	if screen.style != tcell.CursorStyleDefault {
+		t.Errorf("failed to reset cursor style: expected default, got %d", screen.style)
+	}
+}
+
+func TestInputFieldOverwrite(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("abcd")
+	i.SetCursorPosition(1)
+
+	sendInputFieldKey(i, tcell.KeyInsert, 0, tcell.ModNone)
+	if !i.GetOverwriteMode() {
+		t.Error("failed to toggle overwrite mode: expected overwrite mode")
+	}
+
+	// Replace characters
+
+	sendInputFieldKey(i, tcell.KeyRune, 'x', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRune, 'ü', tcell.ModNone)
+	if i.GetText() != "axüd" {
+		t.Errorf("failed to overwrite characters: expected \"axüd\", got %q", i.GetText())
+	} else if i.GetCursorPosition() != 4 {
+		t.Errorf("failed to overwrite characters: expected position 4, got %d", i.GetCursorPosition())
+	}
+
+	// Append at the end of the text
+
+	sendInputFieldKey(i, tcell.KeyRune, 'e', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRune, 'f', tcell.ModNone)
+	if i.GetText() != "axüef" {
+		t.Errorf("failed to append characters in overwrite mode: expected \"axüef\", got %q", i.GetText())
+	}
+
+	// Insert mode
+
+	sendInputFieldKey(i, tcell.KeyInsert, 0, tcell.ModNone)
+	i.SetCursorPosition(0)
+	sendInputFieldKey(i, tcell.KeyRune, 'y', tcell.ModNone)
+	if i.GetOverwriteMode() {
+		t.Error("failed to toggle overwrite mode: expected insert mode")
+	} else if i.GetText() != "yaxüef" {
+		t.Errorf("failed to insert character: expected \"yaxüef\", got %q", i.GetText())
+	}
+
+	// Cursor style
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	screen := &cursorStyleScreen{Screen: app.screen}
+
+	i.SetOverwriteMode(true)
+	i.Focus(func(p Primitive) {})
+	i.Draw(screen)
+	if screen.style != tcell.CursorStyleSteadyBlock {
+		t.Errorf("failed to set overwrite cursor style: expected %d, got %d", tcell.CursorStyleSteadyBlock, screen.style)
+	}
+}
+
+func TestInputFieldMarshalState(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("Hello, world")
+	i.SetCursorPosition(5)
+	data, err := i.MarshalState()
+	if err != nil {
+		t.Fatalf("failed to marshal InputField state: %s", err)
+	}
+
+	restored := NewInputField()
+	if err := restored.UnmarshalState(data); err != nil {
+		t.Fatalf("failed to unmarshal InputField state: %s", err)
+	}
+	if restored.GetText() != "Hello, world" {
+		t.Errorf("failed to restore InputField text: expected \"Hello, world\", got %q", restored.GetText())
+	} else if restored.GetCursorPosition() != 5 {
+		t.Errorf("failed to restore InputField cursor position: expected 5, got %d", restored.GetCursorPosition())
+	}
+
+	// Invalid state
+
+	if err := restored.UnmarshalState([]byte("{")); err == nil {
+		t.Error("failed to reject invalid InputField state: expected error")
+	}
+}
+
+func TestInputFieldAutocompleteMouse(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteMaxHeight(3)
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		var entries []*ListItem
+		for _, entry := range []string{"a1", "a2", "a3", "a4", "a5"} {
+			entries = append(entries, NewListItem(entry))
+		}
+		return entries
+	})
+	i.SetRect(0, 0, 20, 1)
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.Draw(app.screen)
+
+	l := i.GetAutocompleteList()
+	lx, ly, _, _ := l.GetRect()
+	sendMouse := func(action MouseAction, x, y int) {
+		i.MouseHandler()(action, tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	}
+
+	// Scroll
+
+	sendMouse(MouseScrollDown, lx, ly)
+	if offset, _ := l.GetOffset(); offset != 1 {
+		t.Errorf("failed to scroll autocomplete list: expected offset 1, got %d", offset)
+	}
+	sendMouse(MouseScrollUp, lx, ly)
+	sendMouse(MouseScrollDown, lx, ly)
+	sendMouse(MouseScrollDown, lx, ly)
+	if offset, _ := l.GetOffset(); offset != 2 {
+		t.Errorf("failed to scroll autocomplete list: expected offset 2, got %d", offset)
+	}
+
+	// Click
+
+	sendMouse(MouseLeftClick, lx, ly+1)
+	if i.GetText() != "a4" {
+		t.Errorf("failed to select autocomplete entry via mouse: expected a4, got %q", i.GetText())
+	} else if i.GetAutocompleteList() != nil {
+		t.Error("failed to hide autocomplete list after selecting entry via mouse")
+	}
+}
+
+func TestInputFieldError(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("Hello")
+	i.SetErrorColor(tcell.ColorBlue)
+	i.SetError(true)
+	i.SetRect(0, 0, 10, 1)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// Draw
+
+	i.Draw(app.screen)
+	if _, _, style, _ := app.screen.GetContent(0, 0); !hasBackground(style, tcell.ColorBlue) {
+		t.Error("failed to draw input field in error state: expected error color background")
+	}
+
+	// Clear on change
+
+	sendInputFieldKey(i, tcell.KeyRune, '!', tcell.ModNone)
+	if i.HasError() {
+		t.Error("failed to clear error state when text changed")
+	}
+	i.Draw(app.screen)
+	if _, _, style, _ := app.screen.GetContent(0, 0); hasBackground(style, tcell.ColorBlue) {
+		t.Error("failed to draw input field after clearing error state: unexpected error color background")
+	}
+
+	// Keep on change
+
+	i.SetClearErrorOnChange(false)
+	i.SetError(true)
+	i.SetText("World")
+	if !i.HasError() {
+		t.Error("failed to keep error state when text changed")
+	}
+}
+
+func TestInputFieldShowAutocomplete(t *testing.T) {
+	t.Parallel()
+
+	entries := []string{"apple", "banana", "blueberry"}
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		var items []*ListItem
+		for _, entry := range entries {
+			if strings.HasPrefix(entry, currentText) {
+				items = append(items, NewListItem(entry))
+			}
+		}
+		return items
+	})
+
+	// Trigger key
+
+	sendInputFieldKey(i, tcell.KeyRune, 'b', tcell.ModNone)
+	if l := i.GetAutocompleteList(); l == nil || l.GetItemCount() != 2 {
+		t.Fatal("failed to autocomplete: expected 2 entries")
+	}
+	sendInputFieldKey(i, tcell.KeyCtrlSpace, 0, tcell.ModCtrl)
+	if l := i.GetAutocompleteList(); l == nil || l.GetItemCount() != 3 {
+		t.Error("failed to show all autocomplete entries: expected 3 entries")
+	} else if i.GetText() != "b" {
+		t.Errorf("failed to show all autocomplete entries: expected text to be unchanged, got %q", i.GetText())
+	}
+
+	// Show entries
+
+	i.ShowAutocomplete([]*ListItem{NewListItem("cherry")})
+	if l := i.GetAutocompleteList(); l == nil || l.GetItemCount() != 1 {
+		t.Error("failed to show autocomplete entries: expected 1 entry")
+	}
+	i.ShowAutocomplete(nil)
+	if i.GetAutocompleteList() != nil {
+		t.Error("failed to hide autocomplete list: expected nil list")
+	}
+}
+
+func TestInputFieldRestrictToSuggestions(t *testing.T) {
+	t.Parallel()
+
+	entries := []string{"apple", "banana", "blueberry"}
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		var items []*ListItem
+		for _, entry := range entries {
+			if strings.HasPrefix(entry, currentText) {
+				items = append(items, NewListItem(entry))
+			}
+		}
+		return items
+	})
+	i.SetText("apple")
+	i.SetRestrictToSuggestions(true)
+
+	var invalid []string
+	i.SetInvalidEntryFunc(func(text string) {
+		invalid = append(invalid, text)
+	})
+	var doneText string
+	i.SetDoneFunc(func(key tcell.Key) {
+		doneText = i.GetText()
+	})
+	var reason ChangeReason
+	i.SetChangedFuncEx(func(text string, r ChangeReason) {
+		reason = r
+	})
+
+	// Revert
+
+	i.SetText("")
+	sendInputFieldKey(i, tcell.KeyRune, 'x', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if i.GetText() != "" {
+		t.Errorf("failed to revert invalid text: expected \"\", got %q", i.GetText())
+	} else if doneText != "" {
+		t.Errorf("failed to revert invalid text before done handler: expected \"\", got %q", doneText)
+	} else if len(invalid) != 1 || invalid[0] != "x" {
+		t.Errorf("failed to call invalid entry handler: expected [x], got %v", invalid)
+	} else if reason != ChangeReasonProgrammatic {
+		t.Errorf("failed to revert invalid text: incorrect change reason: expected %d, got %d", ChangeReasonProgrammatic, reason)
+	}
+
+	// Valid entry
+
+	i.SetText("banan")
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone) // Hide the autocomplete list.
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if i.GetText() != "banana" {
+		t.Errorf("failed to accept valid text: expected banana, got %q", i.GetText())
+	}
+	sendInputFieldKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone) // Commit the autocomplete entry.
+	sendInputFieldKey(i, tcell.KeyRune, 'z', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if i.GetText() != "banana" {
+		t.Errorf("failed to revert invalid text: expected banana, got %q", i.GetText())
+	}
+
+	// Select closest
+
+	i.SetRestrictAction(RestrictSelectClosest)
+	i.SetText("b")
+	sendInputFieldKey(i, tcell.KeyRune, 'l', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone) // Hide the autocomplete list.
+	sendInputFieldKey(i, tcell.KeyEscape, 0, tcell.ModNone)
+	if i.GetText() != "blueberry" {
+		t.Errorf("failed to select closest entry: expected blueberry, got %q", i.GetText())
+	} else if reason != ChangeReasonAutocomplete {
+		t.Errorf("failed to select closest entry: incorrect change reason: expected %d, got %d", ChangeReasonAutocomplete, reason)
+	}
+
+	// Blur
+
+	i.Focus(func(p Primitive) {})
+	i.SetRestrictAction(RestrictRevert)
+	sendInputFieldKey(i, tcell.KeyRune, 's', tcell.ModNone)
+	i.Blur()
+	if i.GetText() != "blueberry" {
+		t.Errorf("failed to revert invalid text on blur: expected blueberry, got %q", i.GetText())
+	} else if len(invalid) != 4 {
+		t.Errorf("failed to call invalid entry handler: expected 4 calls, got %d", len(invalid))
+	}
+}
+
+func TestInputFieldClearButton(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetShowClearButton(true)
+	i.SetText("hello world")
+	i.SetRect(0, 0, 10, 1)
+
+	var reasons []ChangeReason
+	i.SetChangedFuncEx(func(text string, reason ChangeReason) {
+		reasons = append(reasons, reason)
+	})
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.Draw(app.screen)
+
+	// Draw
+
+	if ch, _, _, _ := app.screen.GetContent(9, 0); ch != '×' {
+		t.Errorf("failed to draw clear button: expected ×, got %q", ch)
+	}
+	if i.cursorScreenX >= 9 {
+		t.Errorf("failed to reserve clear button cell: cursor drawn at %d", i.cursorScreenX)
+	}
+
+	// Click
+
+	var focused Primitive
+	i.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(9, 0, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {
+		focused = p
+	})
+	if i.GetText() != "" {
+		t.Errorf("failed to clear text: expected \"\", got %q", i.GetText())
+	} else if len(reasons) != 1 || reasons[0] != ChangeReasonDelete {
+		t.Errorf("failed to call changed handler: expected [%d], got %v", ChangeReasonDelete, reasons)
+	} else if focused != i {
+		t.Error("failed to keep focus on input field")
+	}
+
+	i.Draw(app.screen)
+	if ch, _, _, _ := app.screen.GetContent(9, 0); ch == '×' {
+		t.Error("failed to hide clear button: expected no button while the text is empty")
+	}
+
+	i.SetClearButtonRune('x')
+	i.SetText("hello")
+	i.Draw(app.screen)
+	if ch, _, _, _ := app.screen.GetContent(9, 0); ch != 'x' {
+		t.Errorf("failed to draw custom clear button: expected x, got %c", ch)
+	}
+}
+
+func TestInputFieldAutocompleteCommitKey(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetAutocompleteFunc(func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		return []*ListItem{NewListItem("a1"), NewListItem("a2"), NewListItem("a3")}
+	})
+
+	// Default
+
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyTab, 0, tcell.ModNone)
+	if l := i.GetAutocompleteList(); l == nil || l.GetCurrentItemIndex() != 1 {
+		t.Fatal("failed to select next autocomplete entry via Tab")
+	}
+
+	// Commit key
+
+	i.SetAutocompleteCommitKey(tcell.KeyTab)
+	sendInputFieldKey(i, tcell.KeyDown, 0, tcell.ModNone)
+	if l := i.GetAutocompleteList(); l == nil || l.GetCurrentItemIndex() != 2 {
+		t.Fatal("failed to select next autocomplete entry via Down")
+	}
+	sendInputFieldKey(i, tcell.KeyTab, 0, tcell.ModNone)
+	if i.GetText() != "a3" {
+		t.Errorf("failed to commit autocomplete entry: expected a3, got %q", i.GetText())
+	} else if i.GetAutocompleteList() != nil {
+		t.Error("failed to hide autocomplete list after committing entry")
+	}
+}
+
+func TestInputFieldFormat(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetFormatFunc(strings.TrimSpace)
+
+	var changed []string
+	i.SetChangedFunc(func(text string) {
+		changed = append(changed, text)
+	})
+	var doneText string
+	i.SetDoneFunc(func(key tcell.Key) {
+		doneText = i.GetText()
+	})
+
+	// Finish
+
+	sendInputFieldKey(i, tcell.KeyRune, ' ', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyRune, 'a', tcell.ModNone)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if i.GetText() != "a" {
+		t.Errorf("failed to format text: expected a, got %q", i.GetText())
+	} else if doneText != "a" {
+		t.Errorf("failed to format text before done handler: expected a, got %q", doneText)
+	} else if len(changed) != 3 || changed[2] != "a" {
+		t.Errorf("failed to call changed handler: expected [\" \" \" a\" a], got %q", changed)
+	}
+
+	// Unchanged
+
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if len(changed) != 3 {
+		t.Errorf("failed to format text: expected no changes, got %q", changed[3:])
+	}
+
+	// Blur
+
+	i.Focus(func(p Primitive) {})
+	sendInputFieldKey(i, tcell.KeyRune, ' ', tcell.ModNone)
+	i.Blur()
+	if i.GetText() != "a" {
+		t.Errorf("failed to format text on blur: expected a, got %q", i.GetText())
+	}
+}
+
+func TestInputFieldGetFieldRect(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetLabel("Name")
+	i.SetFieldWidth(10)
+	i.SetRect(2, 3, 40, 2)
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.Draw(app.screen)
+
+	if x, y, width, height := i.GetFieldRect(); x != 6 || y != 3 || width != 10 || height != 1 {
+		t.Errorf("failed to get field rect: expected (6, 3, 10, 1), got (%d, %d, %d, %d)", x, y, width, height)
+	}
+
+	// Label above
+
+	i.SetLabelPosition(LabelAbove)
+	i.Draw(app.screen)
+	if x, y, width, height := i.GetFieldRect(); x != 2 || y != 4 || width != 10 || height != 1 {
+		t.Errorf("failed to get field rect: expected (2, 4, 10, 1), got (%d, %d, %d, %d)", x, y, width, height)
+	}
+}
+
+func TestInputFieldIgnoreEnter(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("hello")
+	var done, finished int
+	i.SetDoneFunc(func(key tcell.Key) {
+		done++
+	})
+	i.SetFinishedFunc(func(key tcell.Key) {
+		finished++
+	})
+	var captured int
+	i.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			captured++
+		}
+		return event
+	})
+
+	i.SetIgnoreEnter(true)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if done != 0 || finished != 0 {
+		t.Errorf("failed to ignore Enter: expected no done or finished calls, got %d done and %d finished", done, finished)
+	}
+	if captured != 1 {
+		t.Errorf("failed to pass ignored Enter to input capture: expected 1 call, got %d", captured)
+	}
+	if i.GetText() != "hello" {
+		t.Errorf("failed to ignore Enter: incorrect text: expected hello, got %s", i.GetText())
+	}
+
+	i.SetIgnoreEnter(false)
+	sendInputFieldKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+	if done != 1 || finished != 1 {
+		t.Errorf("failed to handle Enter: expected 1 done and 1 finished call, got %d done and %d finished", done, finished)
+	}
+}
+
+func TestInputFieldDirty(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetText("ab")
+	i.SetInitialText("ab")
+	if i.IsDirty() {
+		t.Error("failed to compare text to initial text: expected clean field, got dirty")
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'c', tcell.ModNone)
+	if !i.IsDirty() {
+		t.Error("failed to detect edit: expected dirty field, got clean")
+	}
+
+	sendInputFieldKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+	if i.IsDirty() {
+		t.Error("failed to detect reverted edit: expected clean field, got dirty")
+	}
+
+	sendInputFieldKey(i, tcell.KeyRune, 'd', tcell.ModNone)
+	i.ResetDirty()
+	if i.IsDirty() {
+		t.Error("failed to reset dirty state: expected clean field, got dirty")
+	}
+	if i.GetText() != "abd" {
+		t.Errorf("failed to reset dirty state: incorrect text: expected abd, got %s", i.GetText())
+	}
+
+	sendInputFieldKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+	if !i.IsDirty() {
+		t.Error("failed to detect edit after reset: expected dirty field, got clean")
+	}
+}
+
+func TestInputFieldNoteWrap(t *testing.T) {
+	t.Parallel()
+
+	i := NewInputField()
+	i.SetLabel("A:")
+	i.SetFieldWidth(10)
+	i.SetFieldNote("one two three four")
+
+	app, err := newTestApp(i)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	i.SetRect(0, 0, 20, 4)
+
+	// row returns the text drawn in the given row of the input area.
+	row := func(y int) string {
+		var text []rune
+		for x := 2; x < 12; x++ {
+			ch, _, _, _ := app.screen.GetContent(x, y)
+			text = append(text, ch)
+		}
+		return strings.TrimRight(string(text), " ")
+	}
+
+	if height := i.GetFieldHeight(); height != 3 {
+		t.Errorf("failed to wrap field note: expected field height 3, got %d", height)
+	}
+	app.screen.Clear()
+	i.Draw(app.screen)
+	for y, expected := range []string{"one two", "three four"} {
+		if text := row(y + 1); text != expected {
+			t.Errorf("failed to wrap field note: expected %q in row %d, got %q", expected, y+1, text)
+		}
+	}
+
+	// Unlimited field width
+
+	i.SetFieldWidth(0)
+	if height := i.GetFieldHeight(); height != 2 {
+		t.Errorf("failed to fit field note: expected field height 2, got %d", height)
+	}
+}
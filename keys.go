@@ -33,6 +33,24 @@ type Key struct {
 	MoveNextPage      []string
 
 	ShowContextMenu []string
+
+	// Text input editing
+	MoveLineStart []string
+	MoveLineEnd   []string
+	MoveWordLeft  []string
+	MoveWordRight []string
+	DeleteAll     []string
+	DeleteToEnd   []string
+	DeleteWord    []string
+
+	TransposeCharacters []string
+	Yank                []string
+	YankPop             []string
+	UppercaseWord       []string
+	LowercaseWord       []string
+	CapitalizeWord      []string
+	ToggleOverwrite     []string
+	ShowAutocomplete    []string
 }
 
 // Keys defines the keyboard shortcuts of an application.
@@ -63,6 +81,23 @@ var Keys = Key{
 	MoveNextPage:      []string{"PageDown", "Ctrl+F"},
 
 	ShowContextMenu: []string{"Alt+Enter"},
+
+	MoveLineStart: []string{"Home", "Ctrl+A", "Alt+a"},
+	MoveLineEnd:   []string{"End", "Ctrl+E", "Alt+e"},
+	MoveWordLeft:  []string{"Alt+Left", "Alt+b"},
+	MoveWordRight: []string{"Alt+Right", "Alt+f"},
+	DeleteAll:     []string{"Ctrl+U"},
+	DeleteToEnd:   []string{"Ctrl+K"},
+	DeleteWord:    []string{"Ctrl+W"},
+
+	TransposeCharacters: []string{"Ctrl+T"},
+	Yank:                []string{"Ctrl+Y"},
+	YankPop:             []string{"Alt+y"},
+	UppercaseWord:       []string{"Alt+u"},
+	LowercaseWord:       []string{"Alt+l"},
+	CapitalizeWord:      []string{"Alt+c"},
+	ToggleOverwrite:     []string{"Insert"},
+	ShowAutocomplete:    []string{"Ctrl+Space"},
 }
 
 // HitShortcut returns whether the EventKey provided is present in one or more
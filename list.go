@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// listSearchTimeout is the duration after which the incremental search buffer
+// of a List is reset.
+const listSearchTimeout = time.Second
+
 // ListItem represents an item in a List.
 type ListItem struct {
 	disabled      bool        // Whether or not the list item is selectable.
@@ -17,6 +22,8 @@ type ListItem struct {
 	shortcut      rune        // The key to select the list item directly, 0 if there is no shortcut.
 	selected      func()      // The optional function which is called when the item is selected.
 	reference     interface{} // An optional reference object.
+	mainTextColor tcell.Color // The main text color, ColorUnset to use the color of the list.
+	marked        bool        // Whether or not the item is selected in multi-select mode.
 
 	sync.RWMutex
 }
@@ -24,7 +31,8 @@ type ListItem struct {
 // NewListItem returns a new item for a list.
 func NewListItem(mainText string) *ListItem {
 	return &ListItem{
-		mainText: []byte(mainText),
+		mainText:      []byte(mainText),
+		mainTextColor: ColorUnset,
 	}
 }
 
@@ -104,6 +112,48 @@ func (l *ListItem) SetSelectedFunc(handler func()) {
 	l.selected = handler
 }
 
+// SetMainTextColor sets the color of the item's main text. Set to ColorUnset
+// to use the main text color of the list.
+func (l *ListItem) SetMainTextColor(color tcell.Color) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.mainTextColor = color
+}
+
+// SetEnabled sets whether the item is selectable.
+func (l *ListItem) SetEnabled(enabled bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.disabled = !enabled
+}
+
+// IsEnabled returns whether the item is selectable.
+func (l *ListItem) IsEnabled() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return !l.disabled
+}
+
+// SetMarked sets whether the item is selected in multi-select mode. The
+// selection changed handler of the list is not called.
+func (l *ListItem) SetMarked(marked bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.marked = marked
+}
+
+// IsMarked returns whether the item is selected in multi-select mode.
+func (l *ListItem) IsMarked() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.marked
+}
+
 // SetReference allows you to store a reference of any type in the item
 func (l *ListItem) SetReference(val interface{}) {
 	l.Lock()
@@ -125,9 +175,24 @@ type List struct {
 	*Box
 	*ContextMenu
 
-	// The items of the list.
+	// The items of the list. When a filter is active, these are the items
+	// which match the filter.
 	items []*ListItem
 
+	// All items of the list while a filter is active.
+	allItems []*ListItem
+
+	// Whether or not a filter is active.
+	filtered bool
+
+	// The query of the active filter.
+	filterQuery string
+
+	// An optional function which determines whether an item matches a filter
+	// query. When nil, items whose main text contains the query (ignoring
+	// case) match.
+	filterFunc func(item *ListItem, query string) bool
+
 	// The index of the currently selected item.
 	currentItem int
 
@@ -191,6 +256,23 @@ type List struct {
 	// An optional function which is called when the user presses the Escape key.
 	done func()
 
+	// Whether or not multiple items may be selected by pressing Space.
+	multiSelect bool
+
+	// An optional function which is called when the user selects or deselects
+	// an item in multi-select mode.
+	selectionChanged func(index int, item *ListItem, selected bool)
+
+	// Whether or not typing navigates to the first item starting with the
+	// typed text.
+	searchOnType bool
+
+	// The text typed for the incremental search.
+	searchBuffer string
+
+	// The time of the last key press processed by the incremental search.
+	searchTime time.Time
+
 	// The height of the list the last time it was drawn.
 	height int
 
@@ -314,6 +396,14 @@ func (l *List) RemoveItem(index int) {
 	}
 
 	// Remove item.
+	if l.filtered {
+		for i, item := range l.allItems {
+			if item == l.items[index] {
+				l.allItems = append(l.allItems[:i], l.allItems[i+1:]...)
+				break
+			}
+		}
+	}
 	l.items = append(l.items[:index], l.items[index+1:]...)
 
 	// If there is nothing left, we're done.
@@ -519,6 +609,61 @@ func (l *List) SetSelectedFunc(handler func(int, *ListItem)) {
 	l.selected = handler
 }
 
+// SetMultiSelect sets whether multiple items may be selected. When enabled,
+// pressing Space toggles the selection of the current item and selected items
+// are drawn with a checkmark. Enter still calls the selected handler.
+func (l *List) SetMultiSelect(multiSelect bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.multiSelect = multiSelect
+}
+
+// GetMultiSelect returns whether multiple items may be selected.
+func (l *List) GetMultiSelect() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.multiSelect
+}
+
+// GetSelectedItems returns the indices of the items which are selected in
+// multi-select mode.
+func (l *List) GetSelectedItems() []int {
+	l.RLock()
+	defer l.RUnlock()
+
+	var indices []int
+	for index, item := range l.items {
+		if item.IsMarked() {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// SetSearchOnType sets whether typing navigates to the first item whose main
+// text starts with the typed text, ignoring case. The typed text is reset after
+// a second of inactivity and may be shortened with Backspace. While enabled,
+// typed characters are not interpreted as shortcuts or navigation keys.
+func (l *List) SetSearchOnType(searchOnType bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.searchOnType = searchOnType
+	l.searchBuffer = ""
+}
+
+// SetSelectionChangedFunc sets a function which is called when the user
+// selects or deselects an item in multi-select mode. The function receives the
+// item's index, the item and whether it is now selected.
+func (l *List) SetSelectionChangedFunc(handler func(index int, item *ListItem, selected bool)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.selectionChanged = handler
+}
+
 // SetDoneFunc sets a function which is called when the user presses the Escape
 // key.
 func (l *List) SetDoneFunc(handler func()) {
@@ -558,6 +703,25 @@ func (l *List) AddItem(item *ListItem) {
 func (l *List) InsertItem(index int, item *ListItem) {
 	l.Lock()
 
+	// Insert into all items and filter them again while a filter is active.
+	if l.filtered {
+		if index < 0 {
+			index = len(l.allItems) + index + 1
+		}
+		if index < 0 {
+			index = 0
+		} else if index > len(l.allItems) {
+			index = len(l.allItems)
+		}
+		l.allItems = append(l.allItems, nil)
+		copy(l.allItems[index+1:], l.allItems[index:])
+		l.allItems[index] = item
+
+		l.applyFilter()
+		l.Unlock()
+		return
+	}
+
 	// Shift index to range.
 	if index < 0 {
 		index = len(l.items) + index + 1
@@ -590,13 +754,18 @@ func (l *List) InsertItem(index int, item *ListItem) {
 	}
 }
 
-// GetItem returns the ListItem at the given index.
-// Returns nil when index is out of bounds.
+// GetItem returns the ListItem at the given index. While a filter is active,
+// the index refers to all items, including those which do not match the
+// filter. Returns nil when index is out of bounds.
 func (l *List) GetItem(index int) *ListItem {
-	if index > len(l.items)-1 {
+	items := l.items
+	if l.filtered {
+		items = l.allItems
+	}
+	if index > len(items)-1 {
 		return nil
 	}
-	return l.items[index]
+	return items[index]
 }
 
 // GetItemCount returns the number of items in the list.
@@ -706,11 +875,106 @@ func (l *List) Clear() {
 	defer l.Unlock()
 
 	l.items = nil
+	l.allItems = nil
 	l.currentItem = 0
 	l.itemOffset = 0
 	l.columnOffset = 0
 }
 
+// SetFilterFunc sets a function which determines whether an item matches the
+// query passed to SetFilter. When no function is set, items whose main text
+// contains the query (ignoring case) match.
+func (l *List) SetFilterFunc(handler func(item *ListItem, query string) bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.filterFunc = handler
+	if l.filtered {
+		l.applyFilter()
+	}
+}
+
+// SetFilter hides all items which do not match the given query from display
+// and navigation, without removing them from the list. While a filter is
+// active, GetItemCount, GetCurrentItemIndex and all other functions referring
+// to items by index operate on the matching items only, with the exception of
+// GetItem, which refers to all items.
+func (l *List) SetFilter(query string) {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.filtered {
+		l.allItems = l.items
+		l.filtered = true
+	}
+	l.filterQuery = query
+	l.applyFilter()
+}
+
+// ClearFilter shows all items again after a filter was set via SetFilter.
+func (l *List) ClearFilter() {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.filtered {
+		return
+	}
+
+	var current *ListItem
+	if l.currentItem < len(l.items) {
+		current = l.items[l.currentItem]
+	}
+
+	l.items = l.allItems
+	l.allItems = nil
+	l.filtered = false
+	l.filterQuery = ""
+
+	l.currentItem = 0
+	for index, item := range l.items {
+		if item == current {
+			l.currentItem = index
+			break
+		}
+	}
+	l.updateOffset()
+}
+
+// applyFilter updates the visible items to those matching the active filter.
+// The selected item remains selected if it matches. The caller must hold the
+// lock.
+func (l *List) applyFilter() {
+	var current *ListItem
+	if l.currentItem < len(l.items) {
+		current = l.items[l.currentItem]
+	}
+
+	query := strings.ToLower(l.filterQuery)
+	items := make([]*ListItem, 0, len(l.allItems))
+	for _, item := range l.allItems {
+		var match bool
+		if l.filterFunc != nil {
+			match = l.filterFunc(item, l.filterQuery)
+		} else {
+			match = strings.Contains(strings.ToLower(item.GetMainText()), query)
+		}
+		if match {
+			items = append(items, item)
+		}
+	}
+	l.items = items
+
+	l.currentItem = 0
+	for index, item := range l.items {
+		if item == current {
+			l.currentItem = index
+			break
+		}
+	}
+	l.itemOffset = 0
+	l.updateOffset()
+}
+
 // Focus is called by the application when the primitive receives focus.
 func (l *List) Focus(delegate func(p Primitive)) {
 	l.Box.Focus(delegate)
@@ -963,6 +1227,14 @@ func (l *List) Draw(screen tcell.Screen) {
 			continue
 		}
 
+		if l.multiSelect {
+			marker := []byte("  ")
+			if item.marked {
+				marker = []byte(string(Styles.ListMultiSelectRune) + " ")
+			}
+			mainText = append(marker, mainText...)
+		}
+
 		if index == l.currentItem {
 			if len(l.selectedPrefix) > 0 {
 				mainText = append(l.selectedPrefix, mainText...)
@@ -999,7 +1271,11 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 
 		// Main text.
-		Print(screen, mainText, x, y, width, AlignLeft, l.mainTextColor)
+		mainTextColor := l.mainTextColor
+		if item.mainTextColor != ColorUnset {
+			mainTextColor = item.mainTextColor
+		}
+		Print(screen, mainText, x, y, width, AlignLeft, mainTextColor)
 
 		// Background color of selected text.
 		if index == l.currentItem && (!l.selectedFocusOnly || hasFocus) {
@@ -1013,7 +1289,7 @@ func (l *List) Draw(screen tcell.Screen) {
 			for bx := 0; bx < textWidth; bx++ {
 				m, c, style, _ := screen.GetContent(x+bx, y)
 				fg, _, _ := style.Decompose()
-				if fg == l.mainTextColor {
+				if fg == mainTextColor {
 					fg = l.selectedTextColor
 				}
 				style = SetAttributes(style.Background(l.selectedBackgroundColor).Foreground(fg), l.selectedTextAttributes)
@@ -1133,6 +1409,24 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 				l.Unlock()
 			}
 			return
+		} else if l.multiSelect && HitShortcut(event, Keys.Select2) {
+			if l.currentItem >= 0 && l.currentItem < len(l.items) {
+				item := l.items[l.currentItem]
+				if !item.disabled {
+					item.Lock()
+					item.marked = !item.marked
+					marked := item.marked
+					item.Unlock()
+
+					if l.selectionChanged != nil {
+						l.Unlock()
+						l.selectionChanged(l.currentItem, item, marked)
+						return
+					}
+				}
+			}
+			l.Unlock()
+			return
 		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
 			if l.currentItem >= 0 && l.currentItem < len(l.items) {
 				item := l.items[l.currentItem]
@@ -1145,10 +1439,12 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 					if l.selected != nil {
 						l.Unlock()
 						l.selected(l.currentItem, item)
-						l.Lock()
+						return
 					}
 				}
 			}
+			l.Unlock()
+			return
 		} else if HitShortcut(event, Keys.ShowContextMenu) {
 			defer l.ContextMenu.show(l.currentItem, -1, -1, setFocus)
 		} else if len(l.items) == 0 {
@@ -1156,7 +1452,11 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			return
 		}
 
-		if event.Key() == tcell.KeyRune {
+		previousItem := l.currentItem
+
+		searched := l.searchOnType && l.search(event)
+
+		if !searched && event.Key() == tcell.KeyRune {
 			ch := event.Rune()
 			if ch != ' ' {
 				// It's not a space bar. Is it a shortcut?
@@ -1184,9 +1484,9 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			}
 		}
 
-		previousItem := l.currentItem
-
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		if searched {
+			// The key was processed by the incremental search.
+		} else if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
 			l.transform(TransformFirstItem)
 		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
 			l.transform(TransformLastItem)
@@ -1216,6 +1516,42 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 	})
 }
 
+// search processes a key press for the incremental search and returns whether
+// it was consumed. The caller must hold the lock.
+func (l *List) search(event *tcell.EventKey) bool {
+	expired := time.Since(l.searchTime) > listSearchTimeout
+
+	switch event.Key() {
+	case tcell.KeyRune:
+		if expired {
+			l.searchBuffer = ""
+		}
+		l.searchBuffer += string(event.Rune())
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if expired || l.searchBuffer == "" {
+			return false
+		}
+		runes := []rune(l.searchBuffer)
+		l.searchBuffer = string(runes[:len(runes)-1])
+	default:
+		return false
+	}
+	l.searchTime = time.Now()
+
+	if l.searchBuffer == "" {
+		return true
+	}
+	prefix := strings.ToLower(l.searchBuffer)
+	for index, item := range l.items {
+		if !item.disabled && strings.HasPrefix(strings.ToLower(item.GetMainText()), prefix) {
+			l.currentItem = index
+			l.updateOffset()
+			break
+		}
+	}
+	return true
+}
+
 // indexAtY returns the index of the list item found at the given Y position
 // or a negative value if there is no such list item.
 func (l *List) indexAtY(y int) int {
@@ -2,6 +2,8 @@ package cview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -64,6 +66,65 @@ func TestList(t *testing.T) {
 		t.Errorf("failed to update List: expected secondary text %s, got %s", listTextC, secondaryText)
 	}
 
+	// Filter items
+
+	l.SetCurrentItem(1)
+	l.SetFilter("moon")
+	if l.GetItemCount() != 1 {
+		t.Errorf("failed to filter List: expected item count 1, got %d", l.GetItemCount())
+	} else if l.GetCurrentItem() != itemB {
+		t.Errorf("failed to filter List: expected current item %s, got %s", listTextB, l.GetCurrentItem().GetMainText())
+	} else if l.GetItem(0) != itemA {
+		t.Errorf("failed to filter List: expected item 0 to refer to all items")
+	}
+
+	l.ClearFilter()
+	if l.GetItemCount() != 2 {
+		t.Errorf("failed to clear List filter: expected item count 2, got %d", l.GetItemCount())
+	} else if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to clear List filter: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+
+	// Multi-select
+
+	var selectionChanged int
+	l.SetMultiSelect(true)
+	l.SetSelectionChangedFunc(func(index int, item *ListItem, selected bool) {
+		selectionChanged++
+	})
+	l.SetCurrentItem(1)
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(p Primitive) {})
+	if selected := l.GetSelectedItems(); len(selected) != 1 || selected[0] != 1 {
+		t.Errorf("failed to select List item: expected selected items [1], got %v", selected)
+	} else if selectionChanged != 1 {
+		t.Errorf("failed to select List item: expected selection changed handler to be called once, got %d", selectionChanged)
+	}
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(p Primitive) {})
+	if selected := l.GetSelectedItems(); len(selected) != 0 {
+		t.Errorf("failed to deselect List item: expected no selected items, got %v", selected)
+	}
+
+	// Search on type
+
+	l.SetMultiSelect(false)
+	l.SetSearchOnType(true)
+	l.SetCurrentItem(0)
+	for _, ch := range "GOOD" {
+		l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone), func(p Primitive) {})
+	}
+	if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to search List: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), func(p Primitive) {})
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone), func(p Primitive) {})
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone), func(p Primitive) {})
+	if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to search List: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+	l.SetSearchOnType(false)
+
 	// Draw
 
 	app, err := newTestApp(l)
@@ -73,3 +134,53 @@ func TestList(t *testing.T) {
 
 	l.Draw(app.screen)
 }
+
+func TestListSearchOnTypeSelect(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	for _, text := range []string{"Apple", "Banana", "Berry"} {
+		l.AddItem(NewListItem(text))
+	}
+	l.SetMultiSelect(true)
+	l.SetSearchOnType(true)
+
+	sendKey := func(ch rune) {
+		l.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone), func(p Primitive) {})
+	}
+
+	// Space marks the current item without extending the search.
+
+	sendKey('b')
+	sendKey(' ')
+	if selected := l.GetSelectedItems(); len(selected) != 1 || selected[0] != 1 {
+		t.Errorf("failed to mark List item: expected selected items [1], got %v", selected)
+	} else if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to mark List item: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+	sendKey('e')
+	if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to continue search after marking: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+
+	// Space selects the current item without extending the search.
+
+	var selected int
+	l.SetMultiSelect(false)
+	l.SetSelectedFunc(func(index int, item *ListItem) {
+		selected++
+	})
+	l.SetCurrentItem(1)
+	sendKey(' ')
+	if selected != 1 {
+		t.Errorf("failed to select List item: expected selected handler to be called once, got %d", selected)
+	} else if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to select List item: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+	l.RLock()
+	searchBuffer := l.searchBuffer
+	l.RUnlock()
+	if searchBuffer != "be" {
+		t.Errorf("failed to select List item: expected search buffer \"be\", got %q", searchBuffer)
+	}
+}
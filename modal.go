@@ -6,12 +6,38 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
+// ModalSeverity determines the icon and border color of a Modal.
+type ModalSeverity int
+
+// Modal severities. The icons and colors of each severity are defined in
+// Styles.
+const (
+	ModalSeverityNone ModalSeverity = iota
+	ModalSeverityInfo
+	ModalSeverityWarning
+	ModalSeverityError
+	ModalSeveritySuccess
+)
+
+// ModalButton describes a button of a Modal. Colors which are set to
+// ColorUnset use the button colors of the Modal.
+type ModalButton struct {
+	// The label of the button.
+	Label string
+
+	// The background color of the button when it is not focused.
+	BackgroundColor tcell.Color
+
+	// The text color of the button.
+	TextColor tcell.Color
+}
+
 // Modal is a centered message window used to inform the user or prompt them
 // for an immediate decision. It needs to have at least one button (added via
-// AddButtons) or it will never disappear. You may change the title and
-// appearance of the window by modifying the Frame returned by GetFrame. You
-// may include additional elements within the window by modifying the Form
-// returned by GetForm.
+// AddButtons) or a list of options (set via SetList) or it will never
+// disappear. You may change the title and appearance of the window by
+// modifying the Frame returned by GetFrame. You may include additional
+// elements within the window by modifying the Form returned by GetForm.
 type Modal struct {
 	*Box
 
@@ -21,6 +47,33 @@ type Modal struct {
 	// The Form embedded in the Modal's Frame.
 	form *Form
 
+	// An optional List of options which is shown below the text.
+	list *List
+
+	// An optional primitive which is shown between the text and the buttons.
+	body Primitive
+
+	// The height of the body.
+	bodyHeight int
+
+	// The function which shifted the focus to the Modal, used to move the focus
+	// between the body and the buttons.
+	delegate func(p Primitive)
+
+	// The primitive which had focus before the Modal was focused.
+	previousFocus Primitive
+
+	// Whether to restore the focus to the previously focused primitive when
+	// the Modal is done.
+	restoreFocus bool
+
+	// Optional functions which are called when a button is activated, keyed
+	// by the index of the button.
+	buttonActivated map[int]func()
+
+	// The default button, or nil if there is no default button.
+	defaultButton *Button
+
 	// The message text (original, not word-wrapped).
 	text string
 
@@ -30,29 +83,82 @@ type Modal struct {
 	// The text alignment.
 	textAlign int
 
+	// The icon shown before the text. A value of 0 means no icon.
+	icon rune
+
+	// The help text which is shown in place of the message text when the user
+	// presses F1.
+	helpText string
+
+	// Whether or not the help text is currently shown.
+	showHelp bool
+
+	// The number of lines by which the text is scrolled down.
+	textOffset int
+
+	// The number of lines of text which fit into the Modal the last time it
+	// was drawn.
+	textHeight int
+
+	// Whether or not the Modal is busy. While busy, the buttons are dimmed and
+	// may not be activated.
+	busy bool
+
+	// The width of the Modal. A value of 0 means one third of the width of the
+	// screen, or as wide as needed to fit the buttons.
+	width int
+
+	// The maximum width of the Modal. A value of 0 means no limit.
+	maxWidth int
+
+	// The number of empty rows above the text and below the buttons.
+	paddingTop, paddingBottom int
+
+	// The options used to wrap the text, or nil to use WordWrap.
+	wordWrapOptions *WordWrapOptions
+
+	// The number of cells by which the Modal is moved from its centered or
+	// explicit position.
+	stackOffsetX, stackOffsetY int
+
+	// Whether the Modal is centered on the screen. Otherwise, it is drawn at
+	// positionX and positionY.
+	autoCenter bool
+
+	// The position of the top-left corner of the Modal when it is not
+	// centered.
+	positionX, positionY int
+
 	// The optional callback for when the user clicked one of the buttons. It
 	// receives the index of the clicked button and the button's label.
 	done func(buttonIndex int, buttonLabel string)
 
+	// An optional function which is called after the done handler. It is set
+	// by ModalQueue while the Modal is visible.
+	dismissed func()
+
 	sync.RWMutex
 }
 
 // NewModal returns a new centered message window.
 func NewModal() *Modal {
 	m := &Modal{
-		Box:       NewBox(),
-		textColor: Styles.PrimaryTextColor,
-		textAlign: AlignCenter,
+		Box:           NewBox(),
+		textColor:     Styles.PrimaryTextColor,
+		textAlign:     AlignCenter,
+		paddingTop:    1,
+		paddingBottom: 1,
+		autoCenter:    true,
+		bodyHeight:    5,
 	}
 
 	m.form = NewForm()
 	m.form.SetButtonsAlign(AlignCenter)
 	m.form.SetPadding(0, 0, 0, 0)
 	m.form.SetCancelFunc(func() {
-		if m.done != nil {
-			m.done(-1, "")
-		}
+		m.finish(-1, "")
 	})
+	m.form.setEnterFunc(m.activateDefaultButton)
 
 	m.frame = NewFrame(m.form)
 	m.frame.SetBorder(true)
@@ -116,14 +222,92 @@ func (m *Modal) SetDoneFunc(handler func(buttonIndex int, buttonLabel string)) {
 	m.done = handler
 }
 
+// SetRestoreFocus sets a flag which determines whether the focus is returned
+// to the primitive which had focus before the Modal was shown (see
+// GetPreviousFocus) when the Modal is done. The focus is restored before the
+// done handler is called, so the handler may still shift it elsewhere.
+func (m *Modal) SetRestoreFocus(restore bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.restoreFocus = restore
+}
+
+// GetPreviousFocus returns the primitive which had focus when the Modal was
+// focused via Application.SetFocus, or nil if it is not known.
+func (m *Modal) GetPreviousFocus() Primitive {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.previousFocus
+}
+
+// setPreviousFocus records the primitive which had focus before the Modal was
+// focused. Focus moving within the Modal does not replace it.
+func (m *Modal) setPreviousFocus(p Primitive) {
+	if p == nil || p == Primitive(m) || m.HasFocus() {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.previousFocus = p
+}
+
+// finish restores the focus if enabled and calls the done handler.
+func (m *Modal) finish(buttonIndex int, buttonLabel string) {
+	m.RLock()
+	restore, previous, delegate := m.restoreFocus, m.previousFocus, m.delegate
+	done, dismissed := m.done, m.dismissed
+	m.RUnlock()
+
+	if restore && previous != nil && delegate != nil {
+		delegate(previous)
+	}
+	if done != nil {
+		done(buttonIndex, buttonLabel)
+	}
+	if dismissed != nil {
+		dismissed()
+	}
+}
+
+// setDismissedFunc sets a function which is called after the done handler.
+func (m *Modal) setDismissedFunc(handler func()) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.dismissed = handler
+}
+
 // SetText sets the message text of the window. The text may contain line
 // breaks. Note that words are wrapped, too, based on the final size of the
-// window.
+// window. Text which does not fit on the screen may be scrolled using the
+// PageUp and PageDown keys or the mouse wheel.
 func (m *Modal) SetText(text string) {
 	m.Lock()
 	defer m.Unlock()
 
 	m.text = text
+	if !m.showHelp {
+		m.textOffset = 0
+	}
+}
+
+// SetHelpText sets a text describing the options of the dialog. When a help
+// text is set, pressing F1 shows it in place of the message text. Long help
+// texts may be scrolled using the Up, Down, PageUp and PageDown keys and the
+// mouse wheel. Pressing F1 or Escape returns to the message text. Buttons can
+// not be activated while the help text is shown.
+func (m *Modal) SetHelpText(text string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.helpText = text
+	if text == "" {
+		m.showHelp = false
+	}
 }
 
 // SetTextAlign sets the horizontal alignment of the text. This must be either
@@ -135,6 +319,134 @@ func (m *Modal) SetTextAlign(align int) {
 	m.textAlign = align
 }
 
+// SetBusy sets whether the Modal is busy (e.g. while performing work after a
+// button was selected). While busy, the buttons are dimmed and keyboard and
+// mouse input is ignored, except for the Escape key, which still cancels the
+// Modal. Call SetBusy(false) to restore interactivity.
+func (m *Modal) SetBusy(busy bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.busy = busy
+}
+
+// IsBusy returns whether the Modal is busy.
+func (m *Modal) IsBusy() bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.busy
+}
+
+// SetSeverity sets the icon shown before the text and the border color of the
+// Modal according to the given severity. The icons and colors are defined in
+// Styles and may be overridden using SetIcon and GetFrame().SetBorderColor.
+// ModalSeverityNone removes the icon and restores the default border color.
+func (m *Modal) SetSeverity(severity ModalSeverity) {
+	m.Lock()
+	defer m.Unlock()
+
+	icon, color := rune(0), Styles.BorderColor
+	switch severity {
+	case ModalSeverityInfo:
+		icon, color = Styles.ModalInfoRune, Styles.ModalInfoColor
+	case ModalSeverityWarning:
+		icon, color = Styles.ModalWarningRune, Styles.ModalWarningColor
+	case ModalSeverityError:
+		icon, color = Styles.ModalErrorRune, Styles.ModalErrorColor
+	case ModalSeveritySuccess:
+		icon, color = Styles.ModalSuccessRune, Styles.ModalSuccessColor
+	}
+	m.icon = icon
+	m.frame.SetBorderColor(color)
+}
+
+// SetIcon sets the icon shown before the first line of the text. A value of 0
+// removes the icon.
+func (m *Modal) SetIcon(icon rune) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.icon = icon
+}
+
+// SetWidth sets the width of the Modal, including its border. A value of 0
+// (the default) makes the Modal one third as wide as the screen, or as wide as
+// needed to fit its buttons. The Modal is never wider than the screen.
+func (m *Modal) SetWidth(cells int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.width = cells
+}
+
+// SetMaxWidth sets the maximum width of the Modal, including its border. A
+// value of 0 (the default) means no limit.
+func (m *Modal) SetMaxWidth(cells int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.maxWidth = cells
+}
+
+// SetWordWrapOptions sets the options used to wrap the text of the Modal (see
+// WordWrapEx). By default, the text is wrapped via WordWrap. Lines which exceed
+// the width of the Modal are clipped.
+func (m *Modal) SetWordWrapOptions(options WordWrapOptions) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.wordWrapOptions = &options
+}
+
+// SetVerticalPadding sets the number of empty rows above the text and below
+// the buttons, inside the border of the Modal. The default is 1 row each.
+func (m *Modal) SetVerticalPadding(top, bottom int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if top < 0 {
+		top = 0
+	}
+	if bottom < 0 {
+		bottom = 0
+	}
+	m.paddingTop, m.paddingBottom = top, bottom
+	m.frame.SetPadding(top, bottom, 1, 1)
+}
+
+// SetStackOffset sets the number of cells by which the Modal is moved
+// horizontally and vertically from its centered (or explicit, see
+// SetPosition) position. When showing multiple Modals on top of each other,
+// offset each successive Modal by a multiple of the same amount to cascade
+// them like windows. The Modal is kept within the bounds of the screen.
+func (m *Modal) SetStackOffset(dx, dy int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.stackOffsetX, m.stackOffsetY = dx, dy
+}
+
+// SetAutoCenter sets whether the Modal is centered on the screen (the
+// default). When disabled, the Modal is drawn at the position set via
+// SetPosition, e.g. to pin it to a corner of the screen.
+func (m *Modal) SetAutoCenter(center bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.autoCenter = center
+}
+
+// SetPosition sets the screen position of the top-left corner of the Modal,
+// which is used when auto-centering is disabled via SetAutoCenter. The Modal
+// is kept within the bounds of the screen.
+func (m *Modal) SetPosition(x, y int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.positionX, m.positionY = x, y
+}
+
 // GetForm returns the Form embedded in the window. The returned Form may be
 // modified to include additional elements (e.g. AddInputField, AddFormItem).
 func (m *Modal) GetForm() *Form {
@@ -155,28 +467,338 @@ func (m *Modal) GetFrame() *Frame {
 // AddButtons adds buttons to the window. There must be at least one button and
 // a "done" handler so the window can be closed again.
 func (m *Modal) AddButtons(labels []string) {
+	buttons := make([]ModalButton, len(labels))
+	for index, label := range labels {
+		buttons[index] = ModalButton{
+			Label:           label,
+			BackgroundColor: ColorUnset,
+			TextColor:       ColorUnset,
+		}
+	}
+	m.AddButtonsWithStyle(buttons)
+}
+
+// AddButtonsWithStyle adds buttons to the window, each of which may have its
+// own colors. There should be at least one button and a "done" handler so the
+// window can be closed again.
+func (m *Modal) AddButtonsWithStyle(buttons []ModalButton) {
 	m.Lock()
 	defer m.Unlock()
 
-	for index, label := range labels {
+	for _, b := range buttons {
 		func(i int, l string) {
-			m.form.AddButton(label, func() {
-				if m.done != nil {
-					m.done(i, l)
+			m.form.AddButton(l, func() {
+				m.RLock()
+				activated := m.buttonActivated[i]
+				m.RUnlock()
+
+				if activated != nil {
+					activated()
 				}
+				m.finish(i, l)
 			})
 			button := m.form.GetButton(m.form.GetButtonCount() - 1)
-			button.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-				switch event.Key() {
-				case tcell.KeyDown, tcell.KeyRight:
-					return tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)
-				case tcell.KeyUp, tcell.KeyLeft:
-					return tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone)
-				}
-				return event
-			})
-		}(index, label)
+			button.SetInputCapture(m.buttonInputCapture)
+			button.setStyle(b.BackgroundColor, b.TextColor)
+		}(m.form.GetButtonCount(), b.Label)
+	}
+}
+
+// GetButton returns the button at the given index, starting with 0 for the
+// button that was added first.
+func (m *Modal) GetButton(index int) *Button {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.form.GetButton(index)
+}
+
+// GetButtonCount returns the number of buttons.
+func (m *Modal) GetButtonCount() int {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.form.GetButtonCount()
+}
+
+// SetButtonDisabled sets whether the button at the given index is disabled.
+// Disabled buttons are dimmed, may not be selected and are skipped when moving
+// between the buttons.
+func (m *Modal) SetButtonDisabled(index int, disabled bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	m.form.GetButton(index).SetDisabled(disabled)
+}
+
+// SetButtonActivatedFunc sets a handler which is called when the button at
+// the given index is activated, before the done handler is called. Pass nil to
+// remove the handler.
+func (m *Modal) SetButtonActivatedFunc(index int, handler func()) {
+	m.Lock()
+	defer m.Unlock()
+
+	if handler == nil {
+		delete(m.buttonActivated, index)
+		return
+	}
+	if m.buttonActivated == nil {
+		m.buttonActivated = make(map[int]func())
+	}
+	m.buttonActivated[index] = handler
+}
+
+// SetDefaultButton sets the index of the default button. The default button
+// is focused and drawn in bold. It is also activated when the user presses
+// Enter while the body (see SetBody) or a form item added via GetForm has
+// focus, instead of moving the focus to the next form item. Form items which
+// consume Enter themselves (e.g. a DropDown, which opens its list) do not
+// activate it. Pass -1 to remove the default button.
+func (m *Modal) SetDefaultButton(index int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if index < -1 || index >= m.form.GetButtonCount() {
+		return
+	}
+	if m.defaultButton != nil {
+		m.defaultButton.setLabelAttributes(tcell.AttrNone)
+		m.defaultButton = nil
+	}
+	if index >= 0 {
+		m.defaultButton = m.form.GetButton(index)
+		m.defaultButton.setLabelAttributes(tcell.AttrBold)
+		m.form.SetFocus(m.form.GetFormItemCount() + index)
+	}
+}
+
+// activateDefaultButton activates the default button and returns true, if
+// there is one and it has not been removed from the Form.
+func (m *Modal) activateDefaultButton() bool {
+	m.RLock()
+	button := m.defaultButton
+	m.RUnlock()
+
+	if button == nil {
+		return false
+	}
+	for index := 0; index < m.form.GetButtonCount(); index++ {
+		if m.form.GetButton(index) == button {
+			button.activate()
+			return true
+		}
+	}
+	return false
+}
+
+// SetList sets a list of options which is shown below the text and from
+// which the user may choose one option using the arrow keys and Enter. When an
+// option is chosen, the done handler receives the index of the option (instead
+// of the index of a button) as well as its text. Pressing Escape still calls
+// the done handler with a negative index. Pass nil to remove the list.
+func (m *Modal) SetList(options []string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(options) == 0 {
+		m.list = nil
+		return
+	}
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	l.SetHighlightFullLine(true)
+	for _, option := range options {
+		l.AddItem(NewListItem(option))
+	}
+	l.SetSelectedFunc(func(index int, item *ListItem) {
+		m.finish(index, item.GetMainText())
+	})
+	l.SetDoneFunc(func() {
+		m.finish(-1, "")
+	})
+	l.setContainerCapture(m.captureSectionKeys(l))
+	m.list = l
+}
+
+// SetBody sets a primitive which is shown between the text (and list, if any)
+// and the buttons, e.g. a Table. The body spans the width of the Modal and is
+// as high as set via SetBodyHeight. When the Modal receives focus, the body is
+// focused first, unless there is a list. Tab and Backtab move the focus
+// between the list, the body and the buttons and Escape calls the done handler
+// with a negative index. These keys are processed after the body's input
+// capture function (see Box.SetInputCapture). Pass nil to remove the body.
+func (m *Modal) SetBody(body Primitive) {
+	m.Lock()
+	defer m.Unlock()
+
+	if c, ok := m.body.(containerCapturer); ok && m.body != body {
+		c.setContainerCapture(nil)
+	}
+	m.body = body
+	if c, ok := body.(containerCapturer); ok {
+		c.setContainerCapture(m.captureSectionKeys(body))
+	}
+}
+
+// SetBodyHeight sets the number of rows of the body (see SetBody). The
+// default is 5.
+func (m *Modal) SetBodyHeight(height int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if height < 1 {
+		height = 1
+	}
+	m.bodyHeight = height
+}
+
+// sections returns the list, the body and the form, in the order in which
+// they receive focus. The list and the body are omitted when they are not
+// set. The caller must hold the lock.
+func (m *Modal) sections() []Primitive {
+	var sections []Primitive
+	if m.list != nil {
+		sections = append(sections, m.list)
+	}
+	if m.body != nil {
+		sections = append(sections, m.body)
+	}
+	return append(sections, m.form)
+}
+
+// focusSection shifts the focus from the given section to the next or
+// previous section (see sections).
+func (m *Modal) focusSection(from Primitive, forward bool) {
+	m.RLock()
+	sections := m.sections()
+	m.RUnlock()
+
+	index := len(sections) - 1
+	for i, section := range sections {
+		if section == from {
+			index = i
+		}
+	}
+	if forward {
+		index = (index + 1) % len(sections)
+	} else {
+		index = (index + len(sections) - 1) % len(sections)
+	}
+	m.focusPrimitive(sections[index])
+}
+
+// captureSectionKeys returns a function which processes key events of the
+// list or the body before they are passed to it.
+func (m *Modal) captureSectionKeys(section Primitive) func(event *tcell.EventKey) *tcell.EventKey {
+	return func(event *tcell.EventKey) *tcell.EventKey {
+		event = m.inputCapture(event)
+		if event == nil {
+			return nil
+		}
+
+		switch event.Key() {
+		case tcell.KeyTab, tcell.KeyBacktab:
+			m.focusSection(section, event.Key() == tcell.KeyTab)
+			return nil
+		case tcell.KeyEscape:
+			m.finish(-1, "")
+			return nil
+		case tcell.KeyEnter:
+			if section != Primitive(m.list) && m.activateDefaultButton() {
+				return nil
+			}
+		}
+		return event
+	}
+}
+
+// focusPrimitive shifts the focus to the given primitive within the Modal.
+func (m *Modal) focusPrimitive(p Primitive) {
+	m.RLock()
+	delegate := m.delegate
+	m.RUnlock()
+
+	if delegate != nil {
+		delegate(p)
+	}
+}
+
+// buttonInputCapture processes key events before they are passed to the
+// focused button.
+func (m *Modal) buttonInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	event = m.inputCapture(event)
+	if event == nil {
+		return nil
+	}
+
+	// Move the focus from the first or last button to the list or the body.
+	m.RLock()
+	sections := len(m.sections())
+	m.RUnlock()
+	if sections > 1 {
+		_, button := m.form.GetFocusedItemIndex()
+		key := event.Key()
+		if key == tcell.KeyTab && button == m.form.GetButtonCount()-1 || key == tcell.KeyBacktab && button == 0 {
+			m.focusSection(m.form, key == tcell.KeyTab)
+			return nil
+		}
+	}
+
+	switch event.Key() {
+	case tcell.KeyPgUp, tcell.KeyPgDn: // Scroll text which does not fit.
+		m.Lock()
+		if event.Key() == tcell.KeyPgUp {
+			m.textOffset -= m.textHeight
+		} else {
+			m.textOffset += m.textHeight
+		}
+		m.Unlock()
+		return nil
+	case tcell.KeyDown, tcell.KeyRight:
+		return tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)
+	case tcell.KeyUp, tcell.KeyLeft:
+		return tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone)
+	}
+	return event
+}
+
+// inputCapture processes key events before they are passed to the focused
+// button or list.
+func (m *Modal) inputCapture(event *tcell.EventKey) *tcell.EventKey {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.busy {
+		if event.Key() == tcell.KeyEscape {
+			return event
+		}
+		return nil
+	}
+
+	if event.Key() == tcell.KeyF1 && m.helpText != "" {
+		m.showHelp = !m.showHelp
+		m.textOffset = 0
+		return nil
+	}
+
+	if m.showHelp {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			m.showHelp = false
+			m.textOffset = 0
+		case tcell.KeyUp:
+			m.textOffset--
+		case tcell.KeyDown:
+			m.textOffset++
+		case tcell.KeyPgUp:
+			m.textOffset -= m.textHeight
+		case tcell.KeyPgDn:
+			m.textOffset += m.textHeight
+		}
+		return nil // Buttons may not be activated while help is shown.
 	}
+	return event
 }
 
 // ClearButtons removes all buttons from the window.
@@ -185,6 +807,7 @@ func (m *Modal) ClearButtons() {
 	defer m.Unlock()
 
 	m.form.ClearButtons()
+	m.defaultButton = nil
 }
 
 // SetFocus shifts the focus to the button with the given index.
@@ -197,24 +820,70 @@ func (m *Modal) SetFocus(index int) {
 
 // Focus is called when this primitive receives focus.
 func (m *Modal) Focus(delegate func(p Primitive)) {
+	m.Lock()
+	m.delegate = delegate
+	list, body := m.list, m.body
+	m.Unlock()
+
+	if list != nil {
+		delegate(list)
+		return
+	} else if body != nil {
+		delegate(body)
+		return
+	}
 	delegate(m.form)
 }
 
 // HasFocus returns whether or not this primitive has focus.
 func (m *Modal) HasFocus() bool {
+	m.RLock()
+	list := m.list
+	m.RUnlock()
+
+	if list != nil && list.HasFocus() {
+		return true
+	}
+
+	m.RLock()
+	body := m.body
+	m.RUnlock()
+	if body != nil && body.GetFocusable().HasFocus() {
+		return true
+	}
 	return m.GetForm().HasFocus()
 }
 
-// Draw draws this primitive onto the screen.
-func (m *Modal) Draw(screen tcell.Screen) {
-	if !m.GetVisible() {
-		return
-	}
+// modalLayout describes the size of a Modal and the arrangement of its
+// contents as calculated by layout.
+type modalLayout struct {
+	// The width and height of the Modal, including its border.
+	width, height int
 
-	formItemCount := m.form.GetFormItemCount()
+	// The visible lines of the word-wrapped text.
+	lines []string
 
-	m.Lock()
-	defer m.Unlock()
+	// The number of lines by which the text is scrolled down and the number of
+	// lines of text which fit into the Modal.
+	textOffset, textHeight int
+
+	// The height of the list and of the empty line separating it from the
+	// text.
+	listHeight, listSpacing int
+
+	// The height of the body and of the empty line separating it from the
+	// text and the list.
+	bodyHeight, bodySpacing int
+}
+
+// layout calculates the size of the Modal and the arrangement of its contents
+// on a screen of the given size. The caller must hold the lock.
+func (m *Modal) layout(screenWidth, screenHeight, formItemCount int) *modalLayout {
+	l := &modalLayout{}
+
+	// The number of rows taken by the border, the padding, the space between
+	// the text and the buttons and the buttons themselves.
+	chromeHeight := m.paddingTop + m.paddingBottom + 4
 
 	// Calculate the width of this Modal.
 	buttonsWidth := 0
@@ -222,36 +891,225 @@ func (m *Modal) Draw(screen tcell.Screen) {
 		buttonsWidth += TaggedTextWidth(button.label) + 4 + 2
 	}
 	buttonsWidth -= 2
-	screenWidth, screenHeight := screen.Size()
 	width := screenWidth / 3
-	if width < buttonsWidth {
+	if m.width > 0 {
+		width = m.width - 4
+	} else if width < buttonsWidth {
 		width = buttonsWidth
 	}
+
+	// Make room for the list.
+	if m.list != nil {
+		for index := 0; index < m.list.GetItemCount(); index++ {
+			mainText, _ := m.list.GetItemText(index)
+			if w := TaggedStringWidth(mainText) + 1; w > width && m.width == 0 {
+				width = w
+			}
+		}
+		l.listHeight = m.list.GetItemCount()
+		if maxHeight := (screenHeight - (formItemCount * 2) - chromeHeight) / 2; l.listHeight > maxHeight {
+			l.listHeight = maxHeight
+		}
+		if l.listHeight < 1 {
+			l.listHeight = 1
+		}
+	}
+	if m.maxWidth > 0 && width > m.maxWidth-4 {
+		width = m.maxWidth - 4
+	}
+	if width > screenWidth-4 {
+		width = screenWidth - 4
+	}
+	if width < 1 {
+		width = 1
+	}
 	// width is now without the box border.
 
-	// Reset the text and find out how wide it is.
+	// Find out how wide the text is.
+	text := m.text
+	if m.showHelp {
+		text = m.helpText
+	} else if m.icon != 0 {
+		text = string(m.icon) + " " + text
+	}
+	if m.wordWrapOptions != nil {
+		l.lines = WordWrapEx(text, width, *m.wordWrapOptions)
+	} else {
+		l.lines = WordWrap(text, width)
+	}
+
+	// Separate the text from the list with an empty line.
+	if m.list != nil && len(l.lines) > 0 {
+		l.listSpacing = 1
+	}
+
+	// Separate the body from the text and list with an empty line.
+	if m.body != nil {
+		l.bodyHeight = m.bodyHeight
+		if len(l.lines) > 0 || m.list != nil {
+			l.bodySpacing = 1
+		}
+	}
+
+	// Scroll the text if it does not fit on the screen.
+	l.textHeight = screenHeight - (formItemCount * 2) - chromeHeight - l.listHeight - l.listSpacing - l.bodyHeight - l.bodySpacing
+	if l.textHeight < 1 {
+		l.textHeight = 1
+	}
+	l.textOffset = m.textOffset
+	if l.textOffset > len(l.lines)-l.textHeight {
+		l.textOffset = len(l.lines) - l.textHeight
+	}
+	if l.textOffset < 0 {
+		l.textOffset = 0
+	}
+	if len(l.lines) > l.textHeight {
+		l.lines = l.lines[l.textOffset : l.textOffset+l.textHeight]
+	}
+
+	l.width = width + 4
+	l.height = len(l.lines) + l.listSpacing + l.listHeight + l.bodySpacing + l.bodyHeight + (formItemCount * 2) + chromeHeight
+	return l
+}
+
+// MeasureSize returns the width and height, including the border, which the
+// Modal would have if it was drawn on a screen of the given size. The text is
+// wrapped the same way as in Draw. This may be used to reserve space for the
+// Modal in a layout before it is drawn.
+func (m *Modal) MeasureSize(screenWidth, screenHeight int) (width, height int) {
+	formItemCount := m.form.GetFormItemCount()
+
+	m.RLock()
+	defer m.RUnlock()
+
+	l := m.layout(screenWidth, screenHeight, formItemCount)
+	return l.width, l.height
+}
+
+// Draw draws this primitive onto the screen.
+func (m *Modal) Draw(screen tcell.Screen) {
+	if !m.GetVisible() {
+		return
+	}
+
+	formItemCount := m.form.GetFormItemCount()
+
+	m.Lock()
+	defer m.Unlock()
+
+	// Calculate the size and the arrangement of the contents.
+	screenWidth, screenHeight := screen.Size()
+	l := m.layout(screenWidth, screenHeight, formItemCount)
+	m.textOffset, m.textHeight = l.textOffset, l.textHeight
+
+	// Reset the text.
 	m.frame.Clear()
-	lines := WordWrap(m.text, width)
-	for _, line := range lines {
+	for _, line := range l.lines {
 		m.frame.AddText(line, true, m.textAlign, m.textColor)
 	}
 
+	// Reserve space for the list and the body, which are drawn over these
+	// lines.
+	for i := 0; i < l.listSpacing+l.listHeight+l.bodySpacing+l.bodyHeight; i++ {
+		m.frame.AddText("", true, m.textAlign, m.textColor)
+	}
+
 	// Set the Modal's position and size.
-	height := len(lines) + (formItemCount * 2) + 6
-	width += 4
+	width, height := l.width, l.height
 	x := (screenWidth - width) / 2
 	y := (screenHeight - height) / 2
+	if !m.autoCenter {
+		x = clampOffset(m.positionX, screenWidth-width)
+		y = clampOffset(m.positionY, screenHeight-height)
+	}
+	if m.stackOffsetX != 0 {
+		x = clampOffset(x+m.stackOffsetX, screenWidth-width)
+	}
+	if m.stackOffsetY != 0 {
+		y = clampOffset(y+m.stackOffsetY, screenHeight-height)
+	}
 	m.SetRect(x, y, width, height)
 
 	// Draw the frame.
 	m.frame.SetRect(x, y, width, height)
 	m.frame.Draw(screen)
+
+	// Draw the list.
+	if m.list != nil {
+		m.list.SetRect(x+2, y+1+m.paddingTop+len(l.lines)+l.listSpacing, width-4, l.listHeight)
+		m.list.Draw(screen)
+	}
+
+	// Draw the body.
+	if m.body != nil {
+		m.body.SetRect(x+2, y+1+m.paddingTop+len(l.lines)+l.listSpacing+l.listHeight+l.bodySpacing, width-4, l.bodyHeight)
+		m.body.Draw(screen)
+	}
+
+	// Dim the buttons while busy.
+	if m.busy {
+		for _, button := range m.form.buttons {
+			bx, by, bw, bh := button.GetRect()
+			for cy := by; cy < by+bh; cy++ {
+				for cx := bx; cx < bx+bw; cx++ {
+					mainc, combc, style, _ := screen.GetContent(cx, cy)
+					screen.SetContent(cx, cy, mainc, combc, style.Dim(true))
+				}
+			}
+		}
+	}
 }
 
 // MouseHandler returns the mouse handler for this primitive.
 func (m *Modal) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
-		// Pass mouse events on to the form.
+		m.Lock()
+		busy, showHelp := m.busy, m.showHelp
+		if busy {
+			m.Unlock()
+			return m.InRect(event.Position()), nil
+		}
+		// Scroll text which does not fit.
+		var scrolled bool
+		overList := m.list != nil && m.list.InRect(event.Position())
+		if m.body != nil {
+			bx, by, bw, bh := m.body.GetRect()
+			x, y := event.Position()
+			overList = overList || x >= bx && x < bx+bw && y >= by && y < by+bh
+		}
+		if m.InRect(event.Position()) && !overList {
+			switch action {
+			case MouseScrollUp:
+				m.textOffset--
+				scrolled = true
+			case MouseScrollDown:
+				m.textOffset++
+				scrolled = true
+			}
+		}
+		m.Unlock()
+
+		// Buttons may not be activated while help is shown.
+		if showHelp || scrolled {
+			return m.InRect(event.Position()), nil
+		}
+
+		// Pass mouse events on to the list, the body and the form.
+		m.RLock()
+		list, body := m.list, m.body
+		m.RUnlock()
+		if list != nil {
+			consumed, capture = list.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+		if body != nil {
+			consumed, capture = body.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
 		consumed, capture = m.form.MouseHandler()(action, event, setFocus)
 		if !consumed && action == MouseLeftClick && m.InRect(event.Position()) {
 			setFocus(m)
@@ -260,3 +1118,15 @@ func (m *Modal) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		return
 	})
 }
+
+// clampOffset returns pos limited to the range 0 to limit. When limit is
+// negative, 0 is returned.
+func clampOffset(pos, limit int) int {
+	if pos > limit {
+		pos = limit
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	return pos
+}
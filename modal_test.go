@@ -0,0 +1,765 @@
+package cview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	testModalText    = "Hello, world!"
+	testModalButtonA = "OK"
+	testModalButtonB = "Cancel"
+)
+
+func TestModal(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA, testModalButtonB})
+	if m.GetForm().GetButtonCount() != 2 {
+		t.Errorf("failed to add Modal buttons: incorrect button count: expected 2, got %d", m.GetForm().GetButtonCount())
+	}
+
+	doneIndex, doneLabel := -2, ""
+	m.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneIndex, doneLabel = buttonIndex, buttonLabel
+	})
+
+	// Draw
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	m.Draw(app.screen)
+
+	// Select button
+
+	m.GetForm().GetButton(1).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 1 || doneLabel != testModalButtonB {
+		t.Errorf("failed to select Modal button: incorrect done arguments: expected 1 %s, got %d %s", testModalButtonB, doneIndex, doneLabel)
+	}
+
+	// Buttons
+
+	if m.GetButtonCount() != 2 {
+		t.Errorf("failed to get Modal button count: expected 2, got %d", m.GetButtonCount())
+	} else if m.GetButton(1).GetLabel() != testModalButtonB {
+		t.Errorf("failed to get Modal button: expected %s, got %s", testModalButtonB, m.GetButton(1).GetLabel())
+	}
+
+	var activated bool
+	m.SetButtonActivatedFunc(0, func() {
+		activated = true
+	})
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if !activated || doneIndex != 0 {
+		t.Errorf("failed to activate Modal button: expected activated handler and done index 0, got %t and %d", activated, doneIndex)
+	}
+
+	// Disabled buttons
+
+	m.SetButtonDisabled(0, true)
+	doneIndex = -2
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -2 {
+		t.Errorf("failed to disable Modal button: expected done handler not to be called, got index %d", doneIndex)
+	}
+
+	var focused Primitive
+	m.GetForm().SetFocus(0)
+	m.GetForm().Focus(func(p Primitive) {
+		focused = p
+	})
+	if focused != m.GetButton(1) {
+		t.Errorf("failed to skip disabled Modal button: expected second button to be focused, got %v", focused)
+	}
+	m.SetButtonDisabled(0, false)
+
+	// Body
+
+	body := NewTextView()
+	body.SetText("Body")
+	m.SetBody(body)
+	m.SetBodyHeight(2)
+	m.Draw(app.screen)
+
+	_, y, _, height := body.GetRect()
+	_, my, _, _ := m.frame.GetRect()
+	if height != 2 || y != my+4 {
+		t.Errorf("failed to draw Modal body: incorrect position: expected y %d height 2, got y %d height %d", my+4, y, height)
+	}
+
+	m.Focus(func(p Primitive) {
+		focused = p
+	})
+	if focused != body {
+		t.Errorf("failed to focus Modal body: expected body, got %v", focused)
+	}
+
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), func(p Primitive) {})
+	if focused != m.form {
+		t.Errorf("failed to move focus from Modal body to buttons: expected form, got %v", focused)
+	}
+
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -1 {
+		t.Errorf("failed to dismiss Modal from body: incorrect done index: expected -1, got %d", doneIndex)
+	}
+
+	var captured int
+	body.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		captured++
+		return event
+	})
+	doneIndex = -2
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if captured != 1 || doneIndex != -1 {
+		t.Errorf("failed to dismiss Modal from body with input capture: expected capture 1 and done index -1, got capture %d and done index %d", captured, doneIndex)
+	}
+	body.SetInputCapture(nil)
+
+	m.SetList([]string{"Option"})
+	m.list.InputHandler()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), func(p Primitive) {})
+	if focused != body {
+		t.Errorf("failed to move focus from Modal list to body: expected body, got %v", focused)
+	}
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone), func(p Primitive) {})
+	if focused != m.list {
+		t.Errorf("failed to move focus from Modal body to list: expected list, got %v", focused)
+	}
+	m.SetList(nil)
+
+	m.SetBody(nil)
+	doneIndex = -2
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -2 {
+		t.Errorf("failed to remove Modal body: expected done handler not to be called, got index %d", doneIndex)
+	}
+
+	// Restore focus
+
+	input := NewInputField()
+	restoreModal := NewModal()
+	restoreModal.AddButtons([]string{testModalButtonA})
+	restoreModal.SetRestoreFocus(true)
+
+	flex := NewFlex()
+	flex.AddItem(input, 1, 0, true)
+	flex.AddItem(restoreModal, 0, 1, false)
+
+	app, err = newTestApp(flex)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	app.SetFocus(restoreModal)
+	if restoreModal.GetPreviousFocus() != input {
+		t.Errorf("failed to record previously focused primitive: expected InputField, got %v", restoreModal.GetPreviousFocus())
+	}
+
+	restoreModal.GetForm().GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if app.GetFocus() != input {
+		t.Errorf("failed to restore focus: expected InputField, got %v", app.GetFocus())
+	}
+}
+
+func TestModalTextAlign(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// textX returns the column of the first character of the text.
+	textX := func() int {
+		m.Draw(app.screen)
+		x, y, width, _ := m.GetRect()
+		for cx := x; cx < x+width; cx++ {
+			if ch, _, _, _ := app.screen.GetContent(cx, y+2); ch == rune(testModalText[0]) {
+				return cx - x
+			}
+		}
+		return -1
+	}
+
+	if x := textX(); x <= 2 {
+		t.Errorf("failed to center Modal text: got column %d", x)
+	}
+	m.SetTextAlign(AlignLeft)
+	if x := textX(); x != 2 {
+		t.Errorf("failed to align Modal text to the left: expected column 2, got %d", x)
+	}
+}
+
+func TestModalHelpText(t *testing.T) {
+	t.Parallel()
+
+	const testModalHelpText = "Press OK"
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.SetHelpText(testModalHelpText)
+	m.AddButtons([]string{testModalButtonA})
+	doneIndex := -2
+	m.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneIndex = buttonIndex
+	})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	// textRow returns the first row of the text area.
+	textRow := func() string {
+		m.Draw(app.screen)
+		x, y, width, _ := m.GetRect()
+		var b strings.Builder
+		for cx := x; cx < x+width; cx++ {
+			ch, _, _, _ := app.screen.GetContent(cx, y+2)
+			b.WriteRune(ch)
+		}
+		return b.String()
+	}
+
+	send := func(key tcell.Key) {
+		m.GetButton(0).InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p Primitive) {})
+	}
+
+	send(tcell.KeyF1)
+	if row := textRow(); !strings.Contains(row, testModalHelpText) {
+		t.Errorf("failed to show Modal help text: expected %q, got %q", testModalHelpText, row)
+	}
+
+	send(tcell.KeyEnter)
+	if doneIndex != -2 {
+		t.Errorf("failed to block Modal buttons while help is shown: expected done handler not to be called, got index %d", doneIndex)
+	}
+
+	send(tcell.KeyF1)
+	if row := textRow(); !strings.Contains(row, testModalText) {
+		t.Errorf("failed to hide Modal help text with F1: expected %q, got %q", testModalText, row)
+	}
+
+	send(tcell.KeyF1)
+	send(tcell.KeyEscape)
+	if row := textRow(); !strings.Contains(row, testModalText) {
+		t.Errorf("failed to hide Modal help text with Escape: expected %q, got %q", testModalText, row)
+	} else if doneIndex != -2 {
+		t.Errorf("failed to hide Modal help text with Escape: expected done handler not to be called, got index %d", doneIndex)
+	}
+
+	send(tcell.KeyEnter)
+	if doneIndex != 0 {
+		t.Errorf("failed to activate Modal button after hiding help: expected done index 0, got %d", doneIndex)
+	}
+
+	// Without a help text F1 is passed on.
+
+	m.SetHelpText("")
+	send(tcell.KeyF1)
+	if row := textRow(); !strings.Contains(row, testModalText) {
+		t.Errorf("failed to ignore F1 without Modal help text: expected %q, got %q", testModalText, row)
+	}
+}
+
+func TestModalBusy(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+	doneIndex := -2
+	m.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneIndex = buttonIndex
+	})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	m.SetBusy(true)
+	if !m.IsBusy() {
+		t.Error("failed to set Modal busy")
+	}
+	m.Draw(app.screen)
+
+	bx, by, _, _ := m.GetButton(0).GetRect()
+	_, _, style, _ := app.screen.GetContent(bx, by)
+	if _, _, attributes := style.Decompose(); attributes&tcell.AttrDim == 0 {
+		t.Error("failed to dim Modal buttons while busy")
+	}
+
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	m.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(bx+1, by, tcell.ButtonNone, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -2 {
+		t.Errorf("failed to block busy Modal: expected done handler not to be called, got index %d", doneIndex)
+	}
+
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -1 {
+		t.Errorf("failed to cancel busy Modal: expected done index -1, got %d", doneIndex)
+	}
+
+	m.SetBusy(false)
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 0 {
+		t.Errorf("failed to restore Modal interactivity: expected done index 0, got %d", doneIndex)
+	}
+}
+
+func TestModalList(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonB})
+	doneIndex, doneLabel := -2, ""
+	m.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneIndex, doneLabel = buttonIndex, buttonLabel
+	})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	m.Draw(app.screen)
+	_, _, _, height := m.GetRect()
+
+	m.SetList([]string{"A", "B", "C"})
+	m.Draw(app.screen)
+	if _, _, _, listHeight := m.GetRect(); listHeight != height+4 {
+		t.Errorf("failed to draw Modal list: incorrect height: expected %d, got %d", height+4, listHeight)
+	}
+
+	var focused Primitive
+	m.Focus(func(p Primitive) {
+		focused = p
+	})
+	if focused != m.list {
+		t.Fatalf("failed to focus Modal list: expected list, got %v", focused)
+	}
+
+	focused.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	focused.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 1 || doneLabel != "B" {
+		t.Errorf("failed to choose Modal list option: expected 1 B, got %d %s", doneIndex, doneLabel)
+	}
+
+	focused.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -1 {
+		t.Errorf("failed to dismiss Modal from list: expected done index -1, got %d", doneIndex)
+	}
+
+	// Long lists are limited to the screen.
+
+	options := make([]string, 50)
+	for i := range options {
+		options[i] = "Option"
+	}
+	m.SetList(options)
+	m.Draw(app.screen)
+	if _, y, _, height := m.GetRect(); y < 0 || y+height > 24 {
+		t.Errorf("failed to limit Modal list height: expected Modal within screen, got y %d height %d", y, height)
+	} else if _, _, _, listHeight := m.list.GetRect(); listHeight >= 50 {
+		t.Errorf("failed to limit Modal list height: got %d", listHeight)
+	}
+}
+
+func TestModalScrollText(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Line %02d", i)
+	}
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+
+	// Short text is not scrolled.
+
+	m.Draw(app.screen)
+	if _, _, _, height := m.GetRect(); height != 7 {
+		t.Errorf("failed to draw Modal: incorrect height: expected 7, got %d", height)
+	}
+
+	// firstLine returns the first line of the text area.
+	firstLine := func() string {
+		m.Draw(app.screen)
+		x, y, width, _ := m.GetRect()
+		var b strings.Builder
+		for cx := x + 1; cx < x+width-1; cx++ {
+			ch, _, _, _ := app.screen.GetContent(cx, y+2)
+			b.WriteRune(ch)
+		}
+		return strings.TrimSpace(b.String())
+	}
+
+	m.SetText(strings.Join(lines, "\n"))
+	if line := firstLine(); line != lines[0] {
+		t.Errorf("failed to draw long Modal text: expected %q, got %q", lines[0], line)
+	}
+	x, y, _, height := m.GetRect()
+	if y < 0 || y+height > 24 {
+		t.Errorf("failed to limit Modal height: expected Modal within screen, got y %d height %d", y, height)
+	}
+	textHeight := height - 7 + 1
+
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone), func(p Primitive) {})
+	if line := firstLine(); line != lines[textHeight] {
+		t.Errorf("failed to scroll Modal text down: expected %q, got %q", lines[textHeight], line)
+	}
+
+	m.MouseHandler()(MouseScrollUp, tcell.NewEventMouse(x+1, y+2, tcell.WheelUp, tcell.ModNone), func(p Primitive) {})
+	if line := firstLine(); line != lines[textHeight-1] {
+		t.Errorf("failed to scroll Modal text with the mouse wheel: expected %q, got %q", lines[textHeight-1], line)
+	}
+
+	m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone), func(p Primitive) {})
+	if line := firstLine(); line != lines[0] {
+		t.Errorf("failed to scroll Modal text up: expected %q, got %q", lines[0], line)
+	}
+
+	for i := 0; i < 10; i++ {
+		m.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone), func(p Primitive) {})
+	}
+	if line, expected := firstLine(), lines[len(lines)-textHeight]; line != expected {
+		t.Errorf("failed to limit Modal text scrolling: expected %q, got %q", expected, line)
+	}
+}
+
+func TestModalPadding(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	for _, test := range []struct {
+		top, bottom int
+		height      int
+	}{
+		{1, 1, 7},
+		{0, 0, 5},
+		{2, 3, 10},
+	} {
+		m.SetVerticalPadding(test.top, test.bottom)
+		m.Draw(app.screen)
+
+		x, y, width, height := m.GetRect()
+		if height != test.height {
+			t.Errorf("failed to set Modal padding %d, %d: expected height %d, got %d", test.top, test.bottom, test.height, height)
+		}
+		var found bool
+		for cx := x; cx < x+width; cx++ {
+			if ch, _, _, _ := app.screen.GetContent(cx, y+1+test.top); ch == rune(testModalText[0]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("failed to set Modal padding %d, %d: text not drawn at row %d", test.top, test.bottom, 1+test.top)
+		}
+	}
+}
+
+func TestModalSeverity(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText("The quick brown fox jumps over the lazy dog. The quick brown fox jumps over the lazy dog.")
+	m.SetTextAlign(AlignLeft)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// content returns the rune at the given position relative to the Modal
+	// and the foreground color of the top left corner of the border.
+	content := func(dx, dy int) (rune, tcell.Color) {
+		m.Draw(app.screen)
+		x, y, _, _ := m.GetRect()
+		ch, _, _, _ := app.screen.GetContent(x+dx, y+dy)
+		_, _, style, _ := app.screen.GetContent(x, y)
+		fg, _, _ := style.Decompose()
+		return ch, fg
+	}
+
+	m.SetSeverity(ModalSeverityError)
+	if ch, color := content(2, 2); ch != Styles.ModalErrorRune || color != Styles.ModalErrorColor {
+		t.Errorf("failed to apply Modal severity: expected icon %c and border color %v, got %c and %v", Styles.ModalErrorRune, Styles.ModalErrorColor, ch, color)
+	}
+	if ch, _ := content(4, 2); ch != 'T' {
+		t.Errorf("failed to separate Modal icon from text: expected T, got %c", ch)
+	}
+	if ch, _ := content(2, 3); ch == Styles.ModalErrorRune {
+		t.Error("failed to show Modal icon only on the first text line")
+	}
+
+	m.SetIcon('!')
+	if ch, color := content(2, 2); ch != '!' || color != Styles.ModalErrorColor {
+		t.Errorf("failed to set Modal icon: expected icon ! and border color %v, got %c and %v", Styles.ModalErrorColor, ch, color)
+	}
+
+	m.SetSeverity(ModalSeverityNone)
+	if ch, color := content(2, 2); ch != 'T' || color != Styles.BorderColor {
+		t.Errorf("failed to remove Modal severity: expected T and border color %v, got %c and %v", Styles.BorderColor, ch, color)
+	}
+}
+
+func TestModalMeasureSize(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText("The quick brown fox jumps over the lazy dog. The quick brown fox jumps over the lazy dog.")
+	m.AddButtons([]string{testModalButtonA, testModalButtonB})
+
+	width, height := m.MeasureSize(80, 24)
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	m.Draw(app.screen)
+
+	if _, _, w, h := m.GetRect(); w != width || h != height {
+		t.Errorf("failed to measure Modal size: measured %dx%d, drawn %dx%d", width, height, w, h)
+	} else if height <= 7 {
+		t.Errorf("failed to measure Modal size: expected wrapped text, got height %d", height)
+	}
+
+	// Smaller screen
+
+	if w, h := m.MeasureSize(40, 24); w >= width || h <= height {
+		t.Errorf("failed to measure Modal size on smaller screen: expected narrower and taller than %dx%d, got %dx%d", width, height, w, h)
+	}
+}
+
+func TestModalWidth(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	for _, test := range []struct {
+		width, maxWidth, expected int
+	}{
+		{0, 0, 80/3 + 4},
+		{50, 0, 50},
+		{50, 30, 30},
+		{0, 20, 20},
+		{200, 0, 80},
+		{200, 100, 80},
+	} {
+		m.SetWidth(test.width)
+		m.SetMaxWidth(test.maxWidth)
+		m.Draw(app.screen)
+		if _, _, width, _ := m.GetRect(); width != test.expected {
+			t.Errorf("failed to size Modal with width %d and maximum width %d: expected width %d, got %d", test.width, test.maxWidth, test.expected, width)
+		}
+	}
+}
+
+func TestModalPosition(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+	m.SetPosition(3, 4)
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// Centered
+
+	m.Draw(app.screen)
+	if x, y, width, height := m.GetRect(); x != (80-width)/2 || y != (24-height)/2 {
+		t.Errorf("failed to center Modal: got position %d, %d", x, y)
+	}
+
+	// Explicit position
+
+	m.SetAutoCenter(false)
+	m.Draw(app.screen)
+	if x, y, _, _ := m.GetRect(); x != 3 || y != 4 {
+		t.Errorf("failed to position Modal: expected 3, 4, got %d, %d", x, y)
+	}
+
+	// Clamped position
+
+	m.SetPosition(100, -5)
+	m.Draw(app.screen)
+	if x, y, width, _ := m.GetRect(); x != 80-width || y != 0 {
+		t.Errorf("failed to clamp Modal position: expected %d, 0, got %d, %d", 80-width, x, y)
+	}
+}
+
+func TestModalStackOffset(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.SetText(testModalText)
+	m.AddButtons([]string{testModalButtonA})
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	m.Draw(app.screen)
+	centerX, centerY, width, height := m.GetRect()
+
+	// Centered
+
+	m.SetStackOffset(2, 1)
+	m.Draw(app.screen)
+	if x, y, _, _ := m.GetRect(); x != centerX+2 || y != centerY+1 {
+		t.Errorf("failed to offset centered Modal: expected %d, %d, got %d, %d", centerX+2, centerY+1, x, y)
+	}
+
+	// Explicit position
+
+	m.SetAutoCenter(false)
+	m.SetPosition(3, 4)
+	m.SetStackOffset(-2, 3)
+	m.Draw(app.screen)
+	if x, y, _, _ := m.GetRect(); x != 1 || y != 7 {
+		t.Errorf("failed to offset positioned Modal: expected 1, 7, got %d, %d", x, y)
+	}
+
+	// Clamped offset
+
+	m.SetStackOffset(-10, 100)
+	m.Draw(app.screen)
+	if x, y, _, _ := m.GetRect(); x != 0 || y != 24-height {
+		t.Errorf("failed to clamp Modal offset: expected 0, %d, got %d, %d", 24-height, x, y)
+	}
+
+	m.SetStackOffset(100, -10)
+	m.Draw(app.screen)
+	if x, y, _, _ := m.GetRect(); x != 80-width || y != 0 {
+		t.Errorf("failed to clamp Modal offset: expected %d, 0, got %d, %d", 80-width, x, y)
+	}
+}
+
+func TestModalDefaultButton(t *testing.T) {
+	t.Parallel()
+
+	m := NewModal()
+	m.AddButtonsWithStyle([]ModalButton{
+		{Label: testModalButtonA, BackgroundColor: ColorUnset, TextColor: ColorUnset},
+		{Label: testModalButtonB, BackgroundColor: tcell.ColorRed, TextColor: tcell.ColorYellow},
+	})
+	doneIndex := -2
+	m.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneIndex = buttonIndex
+	})
+	m.SetDefaultButton(1)
+
+	body := NewTextView()
+	m.SetBody(body)
+
+	app, err := newTestApp(m)
+	if err != nil {
+		t.Fatalf("failed to initialize Application: %s", err)
+	}
+	m.Draw(app.screen)
+
+	bx, by, _, _ := m.GetButton(1).GetInnerRect()
+	var bold bool
+	for x := bx; x < bx+4; x++ {
+		_, _, style, _ := app.screen.GetContent(x, by)
+		_, bg, attributes := style.Decompose()
+		bold = bold || attributes&tcell.AttrBold != 0
+		if bg != tcell.ColorRed {
+			t.Errorf("failed to apply Modal button background color: expected %v, got %v", tcell.ColorRed, bg)
+			break
+		}
+	}
+	if !bold {
+		t.Error("failed to draw default Modal button in bold")
+	}
+
+	button := m.GetButton(1)
+	button.SetLabelColorFocused(tcell.ColorGreen)
+	button.Focus(func(p Primitive) {})
+	button.Draw(app.screen)
+	_, _, style, _ := app.screen.GetContent(bx+2, by)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorGreen {
+		t.Errorf("failed to apply focused Modal button label color: expected %v, got %v", tcell.ColorGreen, fg)
+	}
+	button.Blur()
+	button.Draw(app.screen)
+	_, _, style, _ = app.screen.GetContent(bx+2, by)
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorYellow {
+		t.Errorf("failed to apply Modal button label color: expected %v, got %v", tcell.ColorYellow, fg)
+	}
+
+	field := NewInputField()
+	m.GetForm().AddFormItem(field)
+	m.GetForm().SetFocus(0)
+	m.GetForm().Focus(func(p Primitive) {})
+	field.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 1 {
+		t.Errorf("failed to activate default Modal button from form item: incorrect done index: expected 1, got %d", doneIndex)
+	}
+	doneIndex = -2
+
+	m.Focus(func(p Primitive) {})
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 1 {
+		t.Errorf("failed to activate default Modal button from body: incorrect done index: expected 1, got %d", doneIndex)
+	}
+
+	doneIndex = -2
+	m.GetForm().RemoveButton(0)
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != 1 {
+		t.Errorf("failed to activate default Modal button after removing another button: incorrect done index: expected 1, got %d", doneIndex)
+	}
+
+	doneIndex = -2
+	m.GetForm().RemoveButton(0)
+	body.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneIndex != -2 {
+		t.Errorf("failed to ignore removed default Modal button: expected done handler not to be called, got index %d", doneIndex)
+	}
+}
@@ -0,0 +1,109 @@
+package cview
+
+import "sync"
+
+// ModalQueue presents Modals one at a time. Modals are shown in the order they
+// were enqueued. The next Modal is presented only after the current Modal has
+// been dismissed via its done handler.
+//
+// ModalQueue does not draw anything by itself. Use GetModal to retrieve the
+// Modal which should currently be visible, and SetChangedFunc to be notified
+// when it changes (e.g. to update Panels and shift focus).
+type ModalQueue struct {
+	// The queued Modals. The first Modal is the one currently visible.
+	modals []*Modal
+
+	// An optional function which is called when the visible Modal changes.
+	changed func(modal *Modal)
+
+	sync.RWMutex
+}
+
+// NewModalQueue returns a new, empty ModalQueue.
+func NewModalQueue() *ModalQueue {
+	return &ModalQueue{}
+}
+
+// SetChangedFunc sets a handler which is called when the visible Modal
+// changes. The handler receives the Modal which should now be shown, or nil
+// when the queue has become empty.
+func (q *ModalQueue) SetChangedFunc(handler func(modal *Modal)) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.changed = handler
+}
+
+// Enqueue adds a Modal to the end of the queue. If the queue was empty, the
+// Modal becomes visible immediately. The queue advances after the done handler
+// of the visible Modal was called.
+func (q *ModalQueue) Enqueue(modal *Modal) {
+	q.Lock()
+	q.modals = append(q.modals, modal)
+	if len(q.modals) > 1 {
+		q.Unlock()
+		return
+	}
+	changed := q.changed
+	q.Unlock()
+
+	q.show(modal)
+	if changed != nil {
+		changed(modal)
+	}
+}
+
+// show advances the queue once the given Modal, which has become visible, is
+// dismissed.
+func (q *ModalQueue) show(modal *Modal) {
+	modal.setDismissedFunc(func() {
+		q.dismiss(modal)
+	})
+}
+
+// GetModal returns the Modal which is currently visible, or nil when the queue
+// is empty.
+func (q *ModalQueue) GetModal() *Modal {
+	q.RLock()
+	defer q.RUnlock()
+
+	if len(q.modals) == 0 {
+		return nil
+	}
+	return q.modals[0]
+}
+
+// GetModalCount returns the number of Modals in the queue, including the
+// Modal which is currently visible.
+func (q *ModalQueue) GetModalCount() int {
+	q.RLock()
+	defer q.RUnlock()
+
+	return len(q.modals)
+}
+
+// dismiss removes the given Modal from the front of the queue and presents
+// the next one.
+func (q *ModalQueue) dismiss(modal *Modal) {
+	q.Lock()
+	if len(q.modals) == 0 || q.modals[0] != modal {
+		q.Unlock()
+		return
+	}
+	q.modals = q.modals[1:]
+
+	var next *Modal
+	if len(q.modals) > 0 {
+		next = q.modals[0]
+	}
+	changed := q.changed
+	q.Unlock()
+
+	modal.setDismissedFunc(nil)
+	if next != nil {
+		q.show(next)
+	}
+	if changed != nil {
+		changed(next)
+	}
+}
@@ -0,0 +1,67 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestModalQueue(t *testing.T) {
+	t.Parallel()
+
+	q := NewModalQueue()
+	if q.GetModal() != nil || q.GetModalCount() != 0 {
+		t.Errorf("failed to initialize ModalQueue: expected no Modal, got %v (%d)", q.GetModal(), q.GetModalCount())
+	}
+
+	var shown []*Modal
+	q.SetChangedFunc(func(modal *Modal) {
+		shown = append(shown, modal)
+	})
+
+	a, b := NewModal(), NewModal()
+	a.AddButtons([]string{testModalButtonA})
+	b.AddButtons([]string{testModalButtonA})
+	q.Enqueue(a)
+	q.Enqueue(b)
+	if q.GetModal() != a || q.GetModalCount() != 2 {
+		t.Errorf("failed to enqueue Modals: expected first Modal of 2, got %v of %d", q.GetModal(), q.GetModalCount())
+	} else if len(shown) != 1 || shown[0] != a {
+		t.Errorf("failed to show first Modal: expected [%v], got %v", a, shown)
+	}
+
+	// Dismissing a Modal which is not visible does not advance the queue.
+	b.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if q.GetModal() != a || len(shown) != 1 {
+		t.Errorf("failed to ignore dismissed queued Modal: expected first Modal, got %v", q.GetModal())
+	}
+
+	// The done handler may be set after the Modal was enqueued.
+	var doneLabel string
+	a.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		doneLabel = buttonLabel
+	})
+	a.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if doneLabel != testModalButtonA {
+		t.Errorf("failed to call done handler: expected %s, got %s", testModalButtonA, doneLabel)
+	} else if q.GetModal() != b || q.GetModalCount() != 1 {
+		t.Errorf("failed to advance ModalQueue: expected second Modal of 1, got %v of %d", q.GetModal(), q.GetModalCount())
+	} else if len(shown) != 2 || shown[1] != b {
+		t.Errorf("failed to show second Modal: expected %v, got %v", b, shown)
+	}
+
+	// A dismissed Modal no longer advances the queue.
+	q.Enqueue(a)
+	b.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	b.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if q.GetModal() != a || q.GetModalCount() != 1 {
+		t.Errorf("failed to ignore dismissed Modal: expected first Modal of 1, got %v of %d", q.GetModal(), q.GetModalCount())
+	}
+
+	a.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+	if q.GetModal() != nil || q.GetModalCount() != 0 {
+		t.Errorf("failed to empty ModalQueue: expected no Modal, got %v (%d)", q.GetModal(), q.GetModalCount())
+	} else if len(shown) != 4 || shown[3] != nil {
+		t.Errorf("failed to notify empty ModalQueue: expected 4 changes ending with nil, got %v", shown)
+	}
+}
@@ -6,7 +6,8 @@ import "github.com/gdamore/tcell/v2"
 type Primitive interface {
 	// Draw draws this primitive onto the screen. Implementers can call the
 	// screen's ShowCursor() function but should only do so when they have focus.
-	// (They will need to keep track of this themselves.)
+	// (They will need to keep track of this themselves.) The cursor style set
+	// via Box.SetCursorStyle should be applied before showing the cursor.
 	Draw(screen tcell.Screen)
 
 	// GetRect returns the current position of the primitive, x, y, width, and
@@ -0,0 +1,478 @@
+package cview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// RadioGroup displays a list of options of which at most one may be selected.
+// Selecting an option deselects the previously selected option.
+type RadioGroup struct {
+	*Box
+
+	// The options, one per line.
+	options [][]byte
+
+	// The index of the selected option, or -1 if no option is selected.
+	selected int
+
+	// The index of the option under the cursor.
+	current int
+
+	// The text to be displayed before the options.
+	label []byte
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The text drawn after the label, e.g. a colon.
+	labelSuffix string
+
+	// Whether the label suffix was set via SetLabelSuffix. The suffix of a
+	// form is then ignored.
+	labelSuffixSet bool
+
+	// The label color.
+	labelColor tcell.Color
+
+	// The label color when focused.
+	labelColorFocused tcell.Color
+
+	// The background color of the input area.
+	fieldBackgroundColor tcell.Color
+
+	// The background color of the input area when focused.
+	fieldBackgroundColorFocused tcell.Color
+
+	// The text color of the input area.
+	fieldTextColor tcell.Color
+
+	// The text color of the input area when focused.
+	fieldTextColorFocused tcell.Color
+
+	// The rune to show within the selected option.
+	selectedRune rune
+
+	// The rune to show within unselected options.
+	unselectedRune rune
+
+	// An optional function which is called when the user selects an option.
+	selectedFunc func(index int, label string)
+
+	// An optional function which is called when the user indicated that they
+	// are done selecting options. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+
+	sync.RWMutex
+}
+
+// NewRadioGroup returns a new radio group without any options.
+func NewRadioGroup() *RadioGroup {
+	return &RadioGroup{
+		Box:                         NewBox(),
+		selected:                    -1,
+		labelColor:                  Styles.SecondaryTextColor,
+		fieldBackgroundColor:        Styles.MoreContrastBackgroundColor,
+		fieldBackgroundColorFocused: Styles.ContrastBackgroundColor,
+		fieldTextColor:              Styles.PrimaryTextColor,
+		selectedRune:                Styles.RadioGroupSelectedRune,
+		unselectedRune:              Styles.RadioGroupUnselectedRune,
+		labelColorFocused:           ColorUnset,
+		fieldTextColorFocused:       ColorUnset,
+	}
+}
+
+// AddOption adds an option to the end of the radio group.
+func (r *RadioGroup) AddOption(label string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.options = append(r.options, []byte(label))
+}
+
+// GetOptionCount returns the number of options in the radio group.
+func (r *RadioGroup) GetOptionCount() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return len(r.options)
+}
+
+// SetSelected selects the option with the given index and moves the cursor to
+// it. Pass -1 to deselect all options. The selected handler is not called.
+func (r *RadioGroup) SetSelected(index int) {
+	r.Lock()
+	defer r.Unlock()
+
+	if index < -1 || index >= len(r.options) {
+		return
+	}
+	r.selected = index
+	if index >= 0 {
+		r.current = index
+	}
+}
+
+// GetSelected returns the index of the selected option, or -1 if no option is
+// selected.
+func (r *RadioGroup) GetSelected() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.selected
+}
+
+// GetFieldValue returns the label of the selected option, or an empty string
+// when no option is selected. This implements FormValuer.
+func (r *RadioGroup) GetFieldValue() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.selected < 0 || r.selected >= len(r.options) {
+		return ""
+	}
+	return string(r.options[r.selected])
+}
+
+// SetFieldValue selects the first option with the given label (see
+// SetSelected). ErrInvalidFieldValue is returned when there is no such option.
+// This implements FormValuer.
+func (r *RadioGroup) SetFieldValue(value string) error {
+	r.RLock()
+	optionIndex := -1
+	for index, option := range r.options {
+		if string(option) == value {
+			optionIndex = index
+			break
+		}
+	}
+	r.RUnlock()
+
+	if optionIndex < 0 {
+		return ErrInvalidFieldValue
+	}
+	r.SetSelected(optionIndex)
+	return nil
+}
+
+// SetSelectedFunc sets a handler which is called when the user selects an
+// option. The handler receives the index and the label of the option.
+func (r *RadioGroup) SetSelectedFunc(handler func(index int, label string)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.selectedFunc = handler
+}
+
+// SetSelectedRune sets the rune to show within the selected option.
+func (r *RadioGroup) SetSelectedRune(rune rune) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.selectedRune = rune
+}
+
+// SetUnselectedRune sets the rune to show within unselected options.
+func (r *RadioGroup) SetUnselectedRune(rune rune) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.unselectedRune = rune
+}
+
+// SetLabel sets the text to be displayed before the options.
+func (r *RadioGroup) SetLabel(label string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.label = []byte(label)
+}
+
+// GetLabel returns the text to be displayed before the options.
+func (r *RadioGroup) GetLabel() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return string(r.label)
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (r *RadioGroup) SetLabelWidth(width int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.labelWidth = width
+}
+
+// SetLabelSuffix sets a text which is drawn after the label, e.g. ":". It
+// overrides the label suffix of the form the radio group is added to.
+func (r *RadioGroup) SetLabelSuffix(suffix string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.labelSuffix = suffix
+	r.labelSuffixSet = true
+}
+
+// setFormLabelSuffix sets the label suffix of the form this radio group belongs
+// to. It is ignored when a suffix was set via SetLabelSuffix.
+func (r *RadioGroup) setFormLabelSuffix(suffix string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.labelSuffixSet {
+		r.labelSuffix = suffix
+	}
+}
+
+// getLabelSuffix returns the text drawn after the label.
+func (r *RadioGroup) getLabelSuffix() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.labelSuffix
+}
+
+// SetLabelColor sets the color of the label.
+func (r *RadioGroup) SetLabelColor(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.labelColor = color
+}
+
+// SetLabelColorFocused sets the color of the label when focused.
+func (r *RadioGroup) SetLabelColorFocused(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.labelColorFocused = color
+}
+
+// SetFieldBackgroundColor sets the background color of the input area.
+func (r *RadioGroup) SetFieldBackgroundColor(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.fieldBackgroundColor = color
+}
+
+// SetFieldBackgroundColorFocused sets the background color of the input area when focused.
+func (r *RadioGroup) SetFieldBackgroundColorFocused(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.fieldBackgroundColorFocused = color
+}
+
+// SetFieldTextColor sets the text color of the input area.
+func (r *RadioGroup) SetFieldTextColor(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.fieldTextColor = color
+}
+
+// SetFieldTextColorFocused sets the text color of the input area when focused.
+func (r *RadioGroup) SetFieldTextColorFocused(color tcell.Color) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.fieldTextColorFocused = color
+}
+
+// GetFieldHeight returns the height of the field.
+func (r *RadioGroup) GetFieldHeight() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	if len(r.options) == 0 {
+		return 1
+	}
+	return len(r.options)
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (r *RadioGroup) GetFieldWidth() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	var maxWidth int
+	for _, option := range r.options {
+		if w := TaggedTextWidth(option); w > maxWidth {
+			maxWidth = w
+		}
+	}
+	return 4 + maxWidth
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// radio group. The callback function is provided with the key that was
+// pressed, which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (r *RadioGroup) SetDoneFunc(handler func(key tcell.Key)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.done = handler
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (r *RadioGroup) SetFinishedFunc(handler func(key tcell.Key)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.finished = handler
+}
+
+// Draw draws this primitive onto the screen.
+func (r *RadioGroup) Draw(screen tcell.Screen) {
+	if !r.GetVisible() {
+		return
+	}
+
+	r.Box.Draw(screen)
+
+	r.Lock()
+	defer r.Unlock()
+
+	hasFocus := r.GetFocusable().HasFocus()
+
+	// Select colors
+	labelColor := r.labelColor
+	fieldBackgroundColor := r.fieldBackgroundColor
+	fieldTextColor := r.fieldTextColor
+	if hasFocus {
+		if r.labelColorFocused != ColorUnset {
+			labelColor = r.labelColorFocused
+		}
+		if r.fieldTextColorFocused != ColorUnset {
+			fieldTextColor = r.fieldTextColorFocused
+		}
+	}
+
+	// Prepare
+	x, y, width, height := r.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	label := suffixedLabel(r.label, r.labelSuffix)
+	if r.labelWidth > 0 {
+		labelWidth := r.labelWidth
+		if labelWidth > rightLimit-x {
+			labelWidth = rightLimit - x
+		}
+		Print(screen, label, x, y, labelWidth, AlignLeft, labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := Print(screen, label, x, y, rightLimit-x, AlignLeft, labelColor)
+		x += drawnWidth
+	}
+
+	// Draw options. The option under the cursor is highlighted when focused.
+	for index, option := range r.options {
+		if index >= height {
+			break
+		}
+		optionBackgroundColor := fieldBackgroundColor
+		if hasFocus && index == r.current && r.fieldBackgroundColorFocused != ColorUnset {
+			optionBackgroundColor = r.fieldBackgroundColorFocused
+		}
+		fieldStyle := tcell.StyleDefault.Background(optionBackgroundColor).Foreground(fieldTextColor)
+
+		optionRune := r.unselectedRune
+		if index == r.selected {
+			optionRune = r.selectedRune
+		}
+		screen.SetContent(x, y+index, ' ', nil, fieldStyle)
+		screen.SetContent(x+1, y+index, optionRune, nil, fieldStyle)
+		screen.SetContent(x+2, y+index, ' ', nil, fieldStyle)
+
+		Print(screen, option, x+4, y+index, rightLimit-x-4, AlignLeft, labelColor)
+	}
+}
+
+// selectCurrent selects the option under the cursor and calls the selected
+// handler.
+func (r *RadioGroup) selectCurrent() {
+	r.Lock()
+	if r.current < 0 || r.current >= len(r.options) {
+		r.Unlock()
+		return
+	}
+	r.selected = r.current
+	index, label := r.selected, string(r.options[r.selected])
+	selectedFunc := r.selectedFunc
+	r.Unlock()
+
+	if selectedFunc != nil {
+		selectedFunc(index, label)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (r *RadioGroup) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return r.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if HitShortcut(event, Keys.Select, Keys.Select2) {
+			r.selectCurrent()
+		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+			r.Lock()
+			if r.current > 0 {
+				r.current--
+			}
+			r.Unlock()
+		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+			r.Lock()
+			if r.current < len(r.options)-1 {
+				r.current++
+			}
+			r.Unlock()
+		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+			if r.done != nil {
+				r.done(event.Key())
+			}
+			if r.finished != nil {
+				r.finished(event.Key())
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (r *RadioGroup) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return r.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Options are drawn below the first line, which may exceed the
+		// primitive's height when it is part of a Form.
+		x, y := event.Position()
+		rectX, rectY, rectWidth, _ := r.GetInnerRect()
+		if x < rectX || x >= rectX+rectWidth || y < rectY || y >= rectY+r.GetFieldHeight() {
+			return false, nil
+		}
+
+		// Process mouse event.
+		if action == MouseLeftClick {
+			setFocus(r)
+			r.Lock()
+			r.current = y - rectY
+			r.Unlock()
+			r.selectCurrent()
+			consumed = true
+		}
+
+		return
+	})
+}
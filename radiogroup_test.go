@@ -0,0 +1,59 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	testRadioGroupOptionA = "Hello, world!"
+	testRadioGroupOptionB = "Goodnight, moon!"
+)
+
+func TestRadioGroup(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	r := NewRadioGroup()
+	if r.GetSelected() != -1 {
+		t.Errorf("failed to initialize RadioGroup: incorrect selection: expected -1, got %d", r.GetSelected())
+	}
+
+	r.AddOption(testRadioGroupOptionA)
+	r.AddOption(testRadioGroupOptionB)
+	if r.GetOptionCount() != 2 {
+		t.Errorf("failed to add RadioGroup options: incorrect option count: expected 2, got %d", r.GetOptionCount())
+	}
+
+	// Select options
+
+	var selectedIndex int
+	var selectedLabel string
+	r.SetSelectedFunc(func(index int, label string) {
+		selectedIndex, selectedLabel = index, label
+	})
+
+	r.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	r.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(p Primitive) {})
+	if r.GetSelected() != 1 {
+		t.Errorf("failed to select RadioGroup option: incorrect selection: expected 1, got %d", r.GetSelected())
+	} else if selectedIndex != 1 || selectedLabel != testRadioGroupOptionB {
+		t.Errorf("failed to select RadioGroup option: incorrect handler arguments: expected 1 %s, got %d %s", testRadioGroupOptionB, selectedIndex, selectedLabel)
+	}
+
+	r.SetSelected(0)
+	if r.GetSelected() != 0 {
+		t.Errorf("failed to set RadioGroup selection: incorrect selection: expected 0, got %d", r.GetSelected())
+	}
+
+	// Draw
+
+	app, err := newTestApp(r)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	r.Draw(app.screen)
+}
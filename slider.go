@@ -2,6 +2,7 @@ package cview
 
 import (
 	"math"
+	"strconv"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
@@ -18,6 +19,13 @@ type Slider struct {
 	// the label text.
 	labelWidth int
 
+	// The text drawn after the label, e.g. a colon.
+	labelSuffix string
+
+	// Whether the label suffix was set via SetLabelSuffix. The suffix of a
+	// form is then ignored.
+	labelSuffixSet bool
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -55,6 +63,10 @@ type Slider struct {
 	// this form item.
 	finished func(tcell.Key)
 
+	// A callback function set by the Form class and called when the value of
+	// this form item changes.
+	formChanged func()
+
 	sync.RWMutex
 }
 
@@ -98,6 +110,35 @@ func (s *Slider) SetLabelWidth(width int) {
 	s.labelWidth = width
 }
 
+// SetLabelSuffix sets a text which is drawn after the label, e.g. ":". It
+// overrides the label suffix of the form the slider is added to.
+func (s *Slider) SetLabelSuffix(suffix string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.labelSuffix = suffix
+	s.labelSuffixSet = true
+}
+
+// setFormLabelSuffix sets the label suffix of the form this slider belongs
+// to. It is ignored when a suffix was set via SetLabelSuffix.
+func (s *Slider) setFormLabelSuffix(suffix string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.labelSuffixSet {
+		s.labelSuffix = suffix
+	}
+}
+
+// getLabelSuffix returns the text drawn after the label.
+func (s *Slider) getLabelSuffix() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.labelSuffix
+}
+
 // SetLabelColor sets the color of the label.
 func (s *Slider) SetLabelColor(color tcell.Color) {
 	s.Lock()
@@ -156,6 +197,52 @@ func (s *Slider) GetFieldWidth() int {
 	return 0
 }
 
+// GetFieldValue returns the value of the slider as a decimal number. This
+// implements FormValuer.
+func (s *Slider) GetFieldValue() string {
+	return strconv.Itoa(s.GetProgress())
+}
+
+// SetFieldValue sets the value of the slider from a decimal number (see
+// SetProgress). ErrInvalidFieldValue is returned when the value is not a
+// number. This implements FormValuer.
+func (s *Slider) SetFieldValue(value string) error {
+	progress, err := strconv.Atoi(value)
+	if err != nil {
+		return ErrInvalidFieldValue
+	}
+	s.SetProgress(progress)
+	return nil
+}
+
+// SetProgressWithCallback sets the value of the slider like SetProgress, and
+// calls the changed handlers when the value differs from the previous value.
+func (s *Slider) SetProgressWithCallback(progress int) {
+	previous := s.GetProgress()
+	s.SetProgress(progress)
+	s.progressChanged(previous)
+}
+
+// progressChanged calls the changed handlers when the value of the slider
+// differs from the given previous value.
+func (s *Slider) progressChanged(previous int) {
+	progress := s.GetProgress()
+	if progress == previous {
+		return
+	}
+
+	s.RLock()
+	changed, formChanged := s.changed, s.formChanged
+	s.RUnlock()
+
+	if changed != nil {
+		changed(progress)
+	}
+	if formChanged != nil {
+		formChanged()
+	}
+}
+
 // SetIncrement sets the amount the slider is incremented by when modified via
 // keyboard.
 func (s *Slider) SetIncrement(increment int) {
@@ -196,6 +283,15 @@ func (s *Slider) SetFinishedFunc(handler func(key tcell.Key)) {
 	s.finished = handler
 }
 
+// setFormChangedFunc sets a callback invoked when the value of this form item
+// changes.
+func (s *Slider) setFormChangedFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.formChanged = handler
+}
+
 // Draw draws this primitive onto the screen.
 func (s *Slider) Draw(screen tcell.Screen) {
 	if !s.GetVisible() {
@@ -233,6 +329,7 @@ func (s *Slider) Draw(screen tcell.Screen) {
 
 	// Draw label.
 	if len(s.label) > 0 {
+		label := suffixedLabel(s.label, s.labelSuffix)
 		if s.vertical {
 			height--
 
@@ -243,11 +340,11 @@ func (s *Slider) Draw(screen tcell.Screen) {
 				if labelWidth > rightLimit-x {
 					labelWidth = rightLimit - x
 				}
-				Print(screen, []byte(s.label), x, y, labelWidth, AlignLeft, labelColor)
+				Print(screen, label, x, y, labelWidth, AlignLeft, labelColor)
 				x += labelWidth + 1
 				width -= labelWidth + 1
 			} else {
-				_, drawnWidth := Print(screen, []byte(s.label), x, y, rightLimit-x, AlignLeft, labelColor)
+				_, drawnWidth := Print(screen, label, x, y, rightLimit-x, AlignLeft, labelColor)
 				x += drawnWidth + 1
 				width -= drawnWidth + 1
 			}
@@ -287,9 +384,7 @@ func (s *Slider) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 			s.AddProgress(s.increment * -1)
 		}
 
-		if s.progress != previous && s.changed != nil {
-			s.changed(s.progress)
-		}
+		s.progressChanged(previous)
 	})
 }
 
@@ -325,10 +420,7 @@ func (s *Slider) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 			}
 			setValue := int(math.Floor(float64(s.max) * (float64(clickPos) / float64(clickRange))))
 			if setValue != s.progress {
-				s.SetProgress(setValue)
-				if s.changed != nil {
-					s.changed(s.progress)
-				}
+				s.SetProgressWithCallback(setValue)
 			}
 		}
 
@@ -16,6 +16,7 @@ type Theme struct {
 	InverseTextColor           tcell.Color // Text on primary-colored backgrounds.
 	ContrastPrimaryTextColor   tcell.Color // Primary text for contrasting elements.
 	ContrastSecondaryTextColor tcell.Color // Secondary text on ContrastBackgroundColor-colored backgrounds.
+	DisabledTextColor          tcell.Color // Text of disabled elements.
 
 	// Background
 	PrimitiveBackgroundColor    tcell.Color // Main background color for primitives.
@@ -26,8 +27,9 @@ type Theme struct {
 	ButtonCursorRune rune // The symbol to draw at the end of button labels when focused.
 
 	// Check box
-	CheckBoxCheckedRune rune
-	CheckBoxCursorRune  rune // The symbol to draw within the checkbox when focused.
+	CheckBoxCheckedRune       rune
+	CheckBoxIndeterminateRune rune // The symbol to draw within a tri-state checkbox when indeterminate.
+	CheckBoxCursorRune        rune // The symbol to draw within the checkbox when focused.
 
 	// Context menu
 	ContextMenuPaddingTop    int
@@ -41,6 +43,31 @@ type Theme struct {
 	DropDownOpenSymbol        rune   // The symbol to draw at the end of the field when opened.
 	DropDownSelectedSymbol    rune   // The symbol to draw to indicate the selected list item.
 
+	// Input field
+	InputFieldErrorColor         tcell.Color   // The background color of the input area in the error state.
+	InputFieldClearButtonRune    rune          // The symbol of the clear button.
+	InputFieldStrengthColors     []tcell.Color // The colors of the strength meter, indexed by score.
+	InputFieldStrengthEmptyColor tcell.Color   // The color of the unfilled part of the strength meter.
+	InputFieldStrengthRune       rune          // The symbol of the filled part of the strength meter.
+	InputFieldStrengthEmptyRune  rune          // The symbol of the unfilled part of the strength meter.
+
+	// List
+	ListMultiSelectRune rune // The symbol to draw before selected items in multi-select mode.
+
+	// Modal
+	ModalInfoColor    tcell.Color // The border color of informational Modals.
+	ModalWarningColor tcell.Color // The border color of warning Modals.
+	ModalErrorColor   tcell.Color // The border color of error Modals.
+	ModalSuccessColor tcell.Color // The border color of success Modals.
+	ModalInfoRune     rune        // The icon shown before the text of informational Modals.
+	ModalWarningRune  rune        // The icon shown before the text of warning Modals.
+	ModalErrorRune    rune        // The icon shown before the text of error Modals.
+	ModalSuccessRune  rune        // The icon shown before the text of success Modals.
+
+	// Radio group
+	RadioGroupSelectedRune   rune // The symbol to draw within the selected option.
+	RadioGroupUnselectedRune rune // The symbol to draw within unselected options.
+
 	// Scroll bar
 	ScrollBarColor tcell.Color
 
@@ -63,6 +90,7 @@ var Styles = Theme{
 	InverseTextColor:           tcell.ColorBlack.TrueColor(),
 	ContrastPrimaryTextColor:   tcell.ColorBlack.TrueColor(),
 	ContrastSecondaryTextColor: tcell.ColorLightSlateGray.TrueColor(),
+	DisabledTextColor:          tcell.ColorGray.TrueColor(),
 
 	PrimitiveBackgroundColor:    tcell.ColorBlack.TrueColor(),
 	ContrastBackgroundColor:     tcell.ColorGreen.TrueColor(),
@@ -70,8 +98,9 @@ var Styles = Theme{
 
 	ButtonCursorRune: '◀',
 
-	CheckBoxCheckedRune: 'X',
-	CheckBoxCursorRune:  '◀',
+	CheckBoxCheckedRune:       'X',
+	CheckBoxIndeterminateRune: '-',
+	CheckBoxCursorRune:        '◀',
 
 	ContextMenuPaddingTop:    0,
 	ContextMenuPaddingBottom: 0,
@@ -83,6 +112,33 @@ var Styles = Theme{
 	DropDownOpenSymbol:        '▼',
 	DropDownSelectedSymbol:    '▶',
 
+	InputFieldErrorColor:      tcell.ColorRed.TrueColor(),
+	InputFieldClearButtonRune: '×',
+	InputFieldStrengthColors: []tcell.Color{
+		tcell.ColorRed.TrueColor(),
+		tcell.ColorOrangeRed.TrueColor(),
+		tcell.ColorOrange.TrueColor(),
+		tcell.ColorYellowGreen.TrueColor(),
+		tcell.ColorLimeGreen.TrueColor(),
+	},
+	InputFieldStrengthEmptyColor: tcell.ColorDarkSlateGray.TrueColor(),
+	InputFieldStrengthRune:       '█',
+	InputFieldStrengthEmptyRune:  '░',
+
+	ListMultiSelectRune: '✔',
+
+	ModalInfoColor:    tcell.ColorDodgerBlue.TrueColor(),
+	ModalWarningColor: tcell.ColorYellow.TrueColor(),
+	ModalErrorColor:   tcell.ColorRed.TrueColor(),
+	ModalSuccessColor: tcell.ColorLimeGreen.TrueColor(),
+	ModalInfoRune:     'ℹ',
+	ModalWarningRune:  '⚠',
+	ModalErrorRune:    '✖',
+	ModalSuccessRune:  '✔',
+
+	RadioGroupSelectedRune:   '●',
+	RadioGroupUnselectedRune: ' ',
+
 	ScrollBarColor: tcell.ColorWhite.TrueColor(),
 
 	WindowMinWidth:  4,
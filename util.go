@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
@@ -64,10 +67,19 @@ var (
 	InputFieldFloat func(text string, ch rune) bool
 
 	// InputFieldMaxLength returns an input field accept handler which accepts
-	// input strings up to a given length. Use it like this:
+	// input strings up to a given length. The length is measured in grapheme
+	// clusters (user-perceived characters). Use it like this:
 	//
 	//   inputField.SetAcceptanceFunc(InputFieldMaxLength(10)) // Accept up to 10 characters.
 	InputFieldMaxLength func(maxLength int) func(text string, ch rune) bool
+
+	// InputFieldRegex returns an input field accept handler which accepts input
+	// strings matching the given regular expression. As the expression is
+	// checked after every character, it must also match incomplete input. Use
+	// it like this:
+	//
+	//   inputField.SetAcceptanceFunc(InputFieldRegex(regexp.MustCompile(`^[a-z]*$`)))
+	InputFieldRegex func(re *regexp.Regexp) func(text string, ch rune) bool
 )
 
 // Transformation describes a widget state modification.
@@ -105,11 +117,33 @@ func init() {
 	}
 	InputFieldMaxLength = func(maxLength int) func(text string, ch rune) bool {
 		return func(text string, ch rune) bool {
-			return len([]rune(text)) <= maxLength
+			return uniseg.GraphemeClusterCount(text) <= maxLength
+		}
+	}
+	InputFieldRegex = func(re *regexp.Regexp) func(text string, ch rune) bool {
+		return func(text string, ch rune) bool {
+			return re.MatchString(text)
 		}
 	}
 }
 
+// SetAmbiguousWidth sets the screen width (1 or 2) of East Asian characters
+// of ambiguous width, such as box-drawing characters and some CJK punctuation.
+// Terminals differ in how they render these characters. Set this to match the
+// terminal to keep the cursor of an InputField and the layout of text aligned
+// with what is shown. The default is 1. Other values are ignored.
+//
+// This changes the width calculation of the whole process, including tcell's.
+// It must not be called concurrently with drawing, e.g. call it before the
+// application is started.
+func SetAmbiguousWidth(width int) {
+	if width != 1 && width != 2 {
+		return
+	}
+	runewidth.DefaultCondition.EastAsianWidth = width == 2
+	runewidth.DefaultCondition.CreateLUT()
+}
+
 // StripTags returns the provided text without color and/or region tags.
 func StripTags(text []byte, colors bool, regions bool) []byte {
 	if !colors && !regions {
@@ -534,6 +568,54 @@ func TaggedStringWidth(text string) int {
 //
 // Issue: https://code.rocketnine.space/tslocum/cview/issues/27
 func WordWrap(text string, width int) (lines []string) {
+	return wordWrap(text, width, true)
+}
+
+// WordWrapOptions control how WordWrapEx splits text.
+type WordWrapOptions struct {
+	// Split words which do not fit on a line, e.g. long URLs. When false, text
+	// is split at whitespace only and longer words exceed the given width.
+	BreakLongWords bool
+
+	// Indent the continuation lines of a paragraph with the whitespace which
+	// the first line of the paragraph is indented with.
+	PreserveIndent bool
+}
+
+// WordWrapEx works like WordWrap but allows controlling how the text is split.
+// WordWrapEx(text, width, WordWrapOptions{BreakLongWords: true}) is equivalent
+// to WordWrap(text, width).
+func WordWrapEx(text string, width int, options WordWrapOptions) (lines []string) {
+	if !options.PreserveIndent {
+		return wordWrap(text, width, options.BreakLongWords)
+	}
+
+	paragraphs := strings.Split(text, "\n")
+	if len(paragraphs) > 1 && paragraphs[len(paragraphs)-1] == "" {
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	for _, paragraph := range paragraphs {
+		body := strings.TrimLeft(paragraph, " \t")
+		if body == "" {
+			lines = append(lines, paragraph)
+			continue
+		}
+		indent := paragraph[:len(paragraph)-len(body)]
+		bodyWidth := width - TaggedStringWidth(indent)
+		if bodyWidth < 1 {
+			bodyWidth = 1
+		}
+		for _, line := range wordWrap(body, bodyWidth, options.BreakLongWords) {
+			lines = append(lines, indent+line)
+		}
+	}
+	return lines
+}
+
+// wordWrap splits a text such that each resulting line does not exceed the
+// given screen width. When breakLongWords is false, the text is split at
+// whitespace only.
+func wordWrap(text string, width int, breakLongWords bool) (lines []string) {
 	colorTagIndices, _, _, _, escapeIndices, strippedText, _ := decomposeText([]byte(text), true, false)
 
 	// Find candidate breakpoints.
@@ -542,6 +624,19 @@ func WordWrap(text string, width int) (lines []string) {
 	// indices into strippedText where a[6] < 0 for newline/punctuation matches
 	// and a[4] < 0 for whitespace matches.
 
+	if !breakLongWords {
+		// Keep newlines and the whitespace following punctuation only.
+		filtered := make([][]int, 0, len(breakpoints))
+		for _, breakpoint := range breakpoints {
+			if breakpoint[4] < 0 || strippedText[breakpoint[4]] == '\n' {
+				filtered = append(filtered, breakpoint)
+			} else if breakpoint[5] < breakpoint[1] {
+				filtered = append(filtered, []int{breakpoint[5], breakpoint[1], breakpoint[5], breakpoint[1], -1, -1, breakpoint[5], breakpoint[1]})
+			}
+		}
+		breakpoints = filtered
+	}
+
 	// Process stripped text one character at a time.
 	var (
 		colorPos, escapePos, breakpointPos, tagOffset      int
@@ -592,7 +687,7 @@ func WordWrap(text string, width int) (lines []string) {
 		}
 
 		// Check if a break is warranted.
-		if forceBreak || lineWidth > 0 && lineWidth+screenWidth > width {
+		if forceBreak || lineWidth > 0 && lineWidth+screenWidth > width && (breakLongWords || lastBreakpoint > currentLineStart) {
 			breakpoint := lastBreakpoint
 			continuation := lastContinuation
 			if forceBreak {
@@ -788,3 +883,78 @@ func RenderScrollBar(screen tcell.Screen, visibility ScrollBarVisibility, x int,
 	}
 	Print(screen, text, x, y, 1, AlignLeft, color)
 }
+
+// isWordRune returns whether the given rune is part of a word. Letters, digits,
+// marks and underscores are considered part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// capitalize returns the given text with the first word character converted
+// to title case and all following word characters converted to lower case.
+func capitalize(text string) string {
+	var b strings.Builder
+	first := true
+	for _, r := range text {
+		if isWordRune(r) && first {
+			r = unicode.ToTitle(r)
+			first = false
+		} else if isWordRune(r) {
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// PrevWordBoundary returns the byte position of the word boundary preceding
+// the given byte position in text. When the character before the position is
+// part of a word, the position of the start of that word is returned.
+// Otherwise, the position of that single character is returned.
+func PrevWordBoundary(text string, pos int) int {
+	if pos > len(text) {
+		pos = len(text)
+	}
+	if pos <= 0 {
+		return 0
+	}
+
+	r, size := utf8.DecodeLastRuneInString(text[:pos])
+	if !isWordRune(r) {
+		return pos - size
+	}
+	for pos > 0 {
+		r, size = utf8.DecodeLastRuneInString(text[:pos])
+		if !isWordRune(r) {
+			break
+		}
+		pos -= size
+	}
+	return pos
+}
+
+// NextWordBoundary returns the byte position of the word boundary following
+// the given byte position in text. When the character at the position is part
+// of a word, the position of the end of that word is returned. Otherwise, the
+// position after that single character is returned.
+func NextWordBoundary(text string, pos int) int {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(text) {
+		return len(text)
+	}
+
+	r, size := utf8.DecodeRuneInString(text[pos:])
+	if !isWordRune(r) {
+		return pos + size
+	}
+	for pos < len(text) {
+		r, size = utf8.DecodeRuneInString(text[pos:])
+		if !isWordRune(r) {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
@@ -1,6 +1,10 @@
 package cview
 
 import (
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -17,3 +21,112 @@ func newTestApp(root Primitive) (*Application, error) {
 
 	return app, nil
 }
+
+func TestWordBoundary(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		text       string
+		pos        int
+		prev, next int
+	}{
+		// Words and punctuation
+		{"foo.bar", 7, 4, 7},
+		{"foo.bar", 4, 3, 7},
+		{"foo.bar", 3, 0, 4},
+		{"foo_bar", 3, 0, 7},
+
+		// Whitespace runs
+		{"foo   bar", 6, 5, 9},
+		{"foo   bar", 3, 0, 4},
+
+		// CJK (3 bytes per character)
+		{"日本語 テキスト", 9, 0, 10},
+		{"日本語 テキスト", 10, 9, 22},
+		{"日本語 テキスト", 3, 0, 9},
+
+		// Bounds
+		{"foo", 0, 0, 3},
+		{"foo", 5, 0, 3},
+		{"", 0, 0, 0},
+	} {
+		if prev := PrevWordBoundary(test.text, test.pos); prev != test.prev {
+			t.Errorf("failed to find previous word boundary in %q at %d: expected %d, got %d", test.text, test.pos, test.prev, prev)
+		}
+		if next := NextWordBoundary(test.text, test.pos); next != test.next {
+			t.Errorf("failed to find next word boundary in %q at %d: expected %d, got %d", test.text, test.pos, test.next, next)
+		}
+	}
+}
+
+func TestInputFieldAcceptanceFuncs(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name     string
+		accept   func(text string, ch rune) bool
+		text     string
+		expected bool
+	}{
+		{"InputFieldInteger", InputFieldInteger, "-12", true},
+		{"InputFieldInteger", InputFieldInteger, "1.5", false},
+		{"InputFieldFloat", InputFieldFloat, "-1.5", true},
+		{"InputFieldFloat", InputFieldFloat, "1.5a", false},
+		{"InputFieldMaxLength", InputFieldMaxLength(3), "a" + testInputFieldFamily + testInputFieldAcute, true},
+		{"InputFieldMaxLength", InputFieldMaxLength(3), "abcd", false},
+		{"InputFieldRegex", InputFieldRegex(regexp.MustCompile(`^[a-z]*$`)), "abc", true},
+		{"InputFieldRegex", InputFieldRegex(regexp.MustCompile(`^[a-z]*$`)), "abC", false},
+	} {
+		lastChar, _ := utf8.DecodeLastRuneInString(test.text)
+		if accepted := test.accept(test.text, lastChar); accepted != test.expected {
+			t.Errorf("failed to check %q with %s: expected %v, got %v", test.text, test.name, test.expected, accepted)
+		}
+	}
+}
+
+func TestWordWrapEx(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		text     string
+		options  WordWrapOptions
+		expected []string
+	}{
+		{"see https://example.com/path ok", WordWrapOptions{}, []string{"see", "https://example.com/path", "ok"}},
+		{"a, b, c, d, e", WordWrapOptions{}, []string{"a, b, c,", "d, e"}},
+		{"  indented text", WordWrapOptions{BreakLongWords: true, PreserveIndent: true}, []string{"  indented", "  text"}},
+		{"a\n\n  b c\n", WordWrapOptions{PreserveIndent: true}, []string{"a", "", "  b c"}},
+	} {
+		lines := WordWrapEx(test.text, 10, test.options)
+		if len(lines) != len(test.expected) {
+			t.Errorf("failed to wrap %q: expected %q, got %q", test.text, test.expected, lines)
+			continue
+		}
+		for index := range lines {
+			if lines[index] != test.expected[index] {
+				t.Errorf("failed to wrap %q: expected %q, got %q", test.text, test.expected, lines)
+				break
+			}
+		}
+	}
+}
+
+// TestSetAmbiguousWidth is not run in parallel because it changes the width
+// calculation of the whole process.
+func TestSetAmbiguousWidth(t *testing.T) {
+	defer SetAmbiguousWidth(1)
+
+	for _, width := range []int{2, 3, 1} {
+		expected := width
+		if width == 3 {
+			expected = 2 // Ignored.
+		}
+		SetAmbiguousWidth(width)
+		if w := TaggedStringWidth("─"); w != expected {
+			t.Errorf("failed to set ambiguous width %d: expected width %d, got %d", width, expected, w)
+		}
+		if w := TaggedStringWidth("a"); w != 1 {
+			t.Errorf("failed to set ambiguous width %d: expected width 1 for narrow character, got %d", width, w)
+		}
+	}
+}